@@ -6,6 +6,7 @@ require (
 	github.com/google/btree v1.1.3
 	github.com/openacid/slim v0.5.11
 	github.com/stretchr/testify v1.7.1
+	golang.org/x/text v0.28.0
 )
 
 require (
@@ -0,0 +1,131 @@
+package rbtree
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// This file contains key codecs used by tree binary serialization
+
+// KeyCodec defines key binary encoding interface used by tree serialization
+type KeyCodec interface {
+	// EncodeKey appends binary representation of the key specified to dst and returns the extended buffer
+	EncodeKey(dst []byte, c Comparable) ([]byte, error)
+
+	// DecodeKey decodes key from its binary representation
+	DecodeKey(data []byte) (Comparable, error)
+}
+
+var (
+	// IntCodec is KeyCodec for Int keys
+	IntCodec KeyCodec = intCodec{}
+
+	// Int64Codec is KeyCodec for Int64 keys
+	Int64Codec KeyCodec = int64Codec{}
+
+	// StringCodec is KeyCodec for *String keys
+	StringCodec KeyCodec = stringCodec{}
+)
+
+// ErrInvalidKey is returned by built-in codecs when the key has unexpected type or
+// binary representation is malformed
+var ErrInvalidKey = errors.New("invalid key")
+
+type intCodec struct{}
+
+type int64Codec struct{}
+
+type stringCodec struct{}
+
+func (intCodec) EncodeKey(dst []byte, c Comparable) ([]byte, error) {
+	v, ok := c.(Int)
+	if !ok {
+		return dst, ErrInvalidKey
+	}
+	return binary.AppendVarint(dst, int64(v)), nil
+}
+
+func (intCodec) DecodeKey(data []byte) (Comparable, error) {
+	v, n := binary.Varint(data)
+	if n != len(data) {
+		return nil, ErrInvalidKey
+	}
+	return Int(v), nil
+}
+
+func (int64Codec) EncodeKey(dst []byte, c Comparable) ([]byte, error) {
+	v, ok := c.(Int64)
+	if !ok {
+		return dst, ErrInvalidKey
+	}
+	return binary.AppendVarint(dst, int64(v)), nil
+}
+
+func (int64Codec) DecodeKey(data []byte) (Comparable, error) {
+	v, n := binary.Varint(data)
+	if n != len(data) {
+		return nil, ErrInvalidKey
+	}
+	return Int64(v), nil
+}
+
+func (stringCodec) EncodeKey(dst []byte, c Comparable) ([]byte, error) {
+	v, ok := c.(*String)
+	if !ok {
+		return dst, ErrInvalidKey
+	}
+	return append(dst, *v...), nil
+}
+
+func (stringCodec) DecodeKey(data []byte) (Comparable, error) {
+	return NewString(string(data)), nil
+}
+
+const (
+	customCodecKind byte = iota
+	intCodecKind
+	int64CodecKind
+	stringCodecKind
+)
+
+// codecKind gets built-in codec identifier written into binary header
+func codecKind(codec KeyCodec) byte {
+	switch codec.(type) {
+	case intCodec:
+		return intCodecKind
+	case int64Codec:
+		return int64CodecKind
+	case stringCodec:
+		return stringCodecKind
+	default:
+		return customCodecKind
+	}
+}
+
+// builtinCodec gets built-in codec by its identifier
+func builtinCodec(kind byte) KeyCodec {
+	switch kind {
+	case intCodecKind:
+		return IntCodec
+	case int64CodecKind:
+		return Int64Codec
+	case stringCodecKind:
+		return StringCodec
+	default:
+		return nil
+	}
+}
+
+// inferCodec gets built-in codec suitable for the key specified
+func inferCodec(c Comparable) KeyCodec {
+	switch c.(type) {
+	case Int:
+		return IntCodec
+	case Int64:
+		return Int64Codec
+	case *String:
+		return StringCodec
+	default:
+		return nil
+	}
+}
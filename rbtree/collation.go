@@ -0,0 +1,211 @@
+package rbtree
+
+import (
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// This file contains collation-aware string keys
+
+// PrefixComparable is the Comparable key that supports prefix matching consistent with its ordering,
+// i.e. all keys having the same prefix are adjacent in the tree and any key that is less than
+// the prefix and doesn't have it precedes them. Such keys can be used with NewPrefixScan
+type PrefixComparable interface {
+	Comparable
+
+	// HasPrefix gets whether the key starts with the prefix specified using the key's collation
+	HasPrefix(prefix Comparable) bool
+}
+
+// FoldString is the case-insensitive string key. Strings are compared rune by rune
+// using Unicode simple case folding so "Apple" and "apple" are equal
+type FoldString string
+
+// NaturalString is the string key that compares digit runs by their numeric values
+// so "file2" is less than "file10". Strings with numerically equal digit runs
+// (like "a01" and "a1") are ordered by their bytes
+type NaturalString string
+
+// NormString is the string key that compares strings in Unicode normalization form C (NFC)
+// so canonically equivalent strings are equal. Use NewNormString to create it
+type NormString struct {
+	s string
+}
+
+// NewNormString creates NormString key normalizing string specified into NFC
+func NewNormString(s string) NormString {
+	return NormString{s: norm.NFC.String(s)}
+}
+
+// Less define Comparable interface member for FoldString
+func (x FoldString) Less(y Comparable) bool {
+	return compareFold(string(x), string(y.(FoldString))) < 0
+}
+
+// Equal define Comparable interface member for FoldString
+func (x FoldString) Equal(y Comparable) bool {
+	v, ok := y.(FoldString)
+	return ok && strings.EqualFold(string(x), string(v))
+}
+
+// HasPrefix gets whether the string starts with the prefix specified ignoring case
+func (x FoldString) HasPrefix(prefix Comparable) bool {
+	p := string(prefix.(FoldString))
+	s := string(x)
+	for p != "" {
+		if s == "" {
+			return false
+		}
+		pr, pn := utf8.DecodeRuneInString(p)
+		sr, sn := utf8.DecodeRuneInString(s)
+		if foldRune(pr) != foldRune(sr) {
+			return false
+		}
+		p = p[pn:]
+		s = s[sn:]
+	}
+	return true
+}
+
+// Less define Comparable interface member for NaturalString
+func (x NaturalString) Less(y Comparable) bool {
+	other := y.(NaturalString)
+	if c := compareNatural(string(x), string(other)); c != 0 {
+		return c < 0
+	}
+	return x < other
+}
+
+// Equal define Comparable interface member for NaturalString
+func (x NaturalString) Equal(y Comparable) bool {
+	return x == y
+}
+
+// HasPrefix gets whether the string starts with the prefix specified. Digit runs are matched as
+// whole numbers so "file10" doesn't start with "file1" while "file1.txt" and "file01" do
+func (x NaturalString) HasPrefix(prefix Comparable) bool {
+	p := string(prefix.(NaturalString))
+	s := string(x)
+	for p != "" {
+		if s == "" {
+			return false
+		}
+		if isDigit(p[0]) {
+			if !isDigit(s[0]) {
+				return false
+			}
+			var pd, sd string
+			pd, p = digitRun(p)
+			sd, s = digitRun(s)
+			if compareNumbers(pd, sd) != 0 {
+				return false
+			}
+			continue
+		}
+		pr, pn := utf8.DecodeRuneInString(p)
+		sr, sn := utf8.DecodeRuneInString(s)
+		if pr != sr {
+			return false
+		}
+		p = p[pn:]
+		s = s[sn:]
+	}
+	return true
+}
+
+// Less define Comparable interface member for NormString
+func (x NormString) Less(y Comparable) bool {
+	return x.s < y.(NormString).s
+}
+
+// Equal define Comparable interface member for NormString
+func (x NormString) Equal(y Comparable) bool {
+	return x == y
+}
+
+// HasPrefix gets whether the normalized string starts with the normalized prefix specified
+func (x NormString) HasPrefix(prefix Comparable) bool {
+	return strings.HasPrefix(x.s, prefix.(NormString).s)
+}
+
+func (x NormString) String() string {
+	return x.s
+}
+
+// compareFold compares strings rune by rune using case folding
+func compareFold(x string, y string) int {
+	for x != "" && y != "" {
+		xr, xn := utf8.DecodeRuneInString(x)
+		yr, yn := utf8.DecodeRuneInString(y)
+		xf := foldRune(xr)
+		yf := foldRune(yr)
+		if xf != yf {
+			if xf < yf {
+				return -1
+			}
+			return 1
+		}
+		x = x[xn:]
+		y = y[yn:]
+	}
+	return len(x) - len(y)
+}
+
+// foldRune gets the smallest rune equivalent to the rune specified under simple case folding
+func foldRune(r rune) rune {
+	min := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < min {
+			min = f
+		}
+	}
+	return min
+}
+
+// compareNatural compares strings comparing digit runs by their numeric values and other runes as is
+func compareNatural(x string, y string) int {
+	for x != "" && y != "" {
+		if isDigit(x[0]) && isDigit(y[0]) {
+			var xd, yd string
+			xd, x = digitRun(x)
+			yd, y = digitRun(y)
+			if c := compareNumbers(xd, yd); c != 0 {
+				return c
+			}
+			continue
+		}
+		xr, xn := utf8.DecodeRuneInString(x)
+		yr, yn := utf8.DecodeRuneInString(y)
+		if xr != yr {
+			return int(xr) - int(yr)
+		}
+		x = x[xn:]
+		y = y[yn:]
+	}
+	return len(x) - len(y)
+}
+
+// compareNumbers compares decimal numbers of any length ignoring leading zeros
+func compareNumbers(x string, y string) int {
+	x = strings.TrimLeft(x, "0")
+	y = strings.TrimLeft(y, "0")
+	if len(x) != len(y) {
+		return len(x) - len(y)
+	}
+	return strings.Compare(x, y)
+}
+
+// digitRun splits string into leading ASCII digits and the rest
+func digitRun(s string) (string, string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
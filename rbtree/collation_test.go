@@ -0,0 +1,162 @@
+package rbtree
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func collect(e Enumerable) []string {
+	result := make([]string, 0)
+	e.Foreach(func(c Comparable) {
+		result = append(result, fmt.Sprint(c))
+	})
+	return result
+}
+
+func Test_FoldString_CaseInsensitiveOrder(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	for _, s := range []string{"banana", "Apple", "cherry", "apricot", "BANANA"} {
+		tree.Insert(FoldString(s))
+	}
+
+	// Act
+	result := collect(NewAscend(tree))
+	_, _, bananas := tree.EqualRange(FoldString("Banana"))
+
+	// Assert
+	ass.Equal([]string{"Apple", "apricot", "banana", "BANANA", "cherry"}, result)
+	ass.Equal(int64(2), bananas)
+}
+
+func Test_FoldString_LessAndEqual(t *testing.T) {
+	var tests = []struct {
+		x     string
+		y     string
+		less  bool
+		equal bool
+	}{
+		{"Apple", "apple", false, true},
+		{"apple", "Banana", true, false},
+		{"Straße", "STRASSE", false, false},
+		{"ΣΊΣΥΦΟΣ", "σίσυφος", false, true},
+		{"abc", "ABCD", true, false},
+	}
+	for _, test := range tests {
+		t.Run(test.x+" "+test.y, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+
+			// Act
+			less := FoldString(test.x).Less(FoldString(test.y))
+			equal := FoldString(test.x).Equal(FoldString(test.y))
+
+			// Assert
+			ass.Equal(test.less, less)
+			ass.Equal(test.equal, equal)
+		})
+	}
+}
+
+func Test_NaturalString_NumericAwareOrder(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	for _, s := range []string{"file10", "file2", "file1", "file01", "file", "file1a", "img12b", "img12a", "file100000000000000000000"} {
+		tree.Insert(NaturalString(s))
+	}
+
+	// Act
+	result := collect(NewAscend(tree))
+
+	// Assert
+	ass.Equal([]string{"file", "file01", "file1", "file1a", "file2", "file10", "file100000000000000000000", "img12a", "img12b"}, result)
+	ass.True(isValidRbTree(tree))
+}
+
+func Test_NormString_CanonicallyEquivalentEqual(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	composed := NewNormString("café")
+	decomposed := NewNormString("cafe\u0301")
+	tree := New()
+	tree.Insert(composed)
+
+	// Act
+	_, ok := tree.Search(decomposed)
+
+	// Assert
+	ass.True(ok)
+	ass.True(composed.Equal(decomposed))
+	ass.Equal("café", decomposed.String())
+}
+
+func Test_NewPrefixScan_Collations(t *testing.T) {
+	var tests = []struct {
+		name     string
+		keys     []Comparable
+		prefix   PrefixComparable
+		expected []string
+	}{
+		{
+			"fold",
+			[]Comparable{FoldString("Apple"), FoldString("apricot"), FoldString("APPLET"), FoldString("banana"), FoldString("ap")},
+			FoldString("aPP"),
+			[]string{"Apple", "APPLET"},
+		},
+		{
+			"natural",
+			[]Comparable{NaturalString("file1"), NaturalString("file10"), NaturalString("file01"), NaturalString("file1.txt"), NaturalString("file2")},
+			NaturalString("file1"),
+			[]string{"file01", "file1", "file1.txt"},
+		},
+		{
+			"natural text prefix",
+			[]Comparable{NaturalString("file1"), NaturalString("file10"), NaturalString("fig"), NaturalString("abc")},
+			NaturalString("fil"),
+			[]string{"file1", "file10"},
+		},
+		{
+			"norm",
+			[]Comparable{NewNormString("café noir"), NewNormString("cafe"), NewNormString("café"), NewNormString("cab")},
+			NewNormString("café"),
+			[]string{"café", "café noir"},
+		},
+		{
+			"nothing",
+			[]Comparable{FoldString("a"), FoldString("b")},
+			FoldString("c"),
+			[]string{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			tree := New()
+			for _, k := range test.keys {
+				tree.Insert(k)
+			}
+
+			// Act
+			result := collect(NewPrefixScan(tree, test.prefix))
+
+			// Assert
+			ass.Equal(test.expected, result)
+		})
+	}
+}
+
+func Test_NewPrefixScan_EmptyTree(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+
+	// Act
+	result := collect(NewPrefixScan(tree, FoldString("a")))
+
+	// Assert
+	ass.Empty(result)
+}
@@ -0,0 +1,25 @@
+// Package compact provides memory efficient Red-black search binary tree implementation
+// that supports ordered statistic. Nodes are stored in a single slice and linked by uint32 indices,
+// node color is packed into the highest bit of the subtree size. Keys of cmp.Ordered types are stored
+// unboxed so a node of an integer key takes 24 bytes instead of a heap allocated rbtree.Node.
+// The tree can hold at most 2^31-1 nodes, inserting more panics with ErrCapacityExceeded.
+//
+// The tree isn't a drop-in replacement of rbtree.RbTree and doesn't implement it. rbtree.RbTree hands out
+// *rbtree.Node handles and rbtree iterators walk their pointers so any implementation (including an adapter
+// over this tree) needs a pointer node per key i.e. exactly the memory this tree saves. Hence
+// rbtree/rbtreetest cannot be run against it. Instead behavior of the key based API is checked
+// against rbtree.RbTree by differential tests. The API deviates from rbtree.RbTree:
+//
+//   - keys are returned instead of *rbtree.Node so there are no node handles and no node based methods
+//     (DeleteNode, UpdateKey, SearchFrom, Successor, Predecessor etc.)
+//   - EqualRange returns ranks of the first and the last equal keys instead of nodes
+//   - ReplaceOrInsert replaces equal key in place and returns the old key with true
+//     instead of deleting it and returning nil when nothing replaced
+//   - Floor and Ceiling return false when there is no such key instead of the tree minimum or maximum
+//     (like rbtree.FrozenTree does), range iterators don't require their ends to be present in the tree
+//     and include all keys equal to their ends
+//   - there are no events, transactions, hashes, codecs and statistics
+//
+// Iterators (NewWalkInorder, NewWalkPreorder, NewWalkPostorder, NewWalkLevelOrder, NewAscend, NewDescend,
+// NewAscendRange, NewDescendRange and NewEqualRange) mirror ones of rbtree package.
+package compact
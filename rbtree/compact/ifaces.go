@@ -0,0 +1,22 @@
+package compact
+
+// Enumerable represents tree enumeration interface
+type Enumerable[K any] interface {
+	// Iterator gets underlying Iterator
+	Iterator() Iterator[K]
+
+	// Foreach does tree iteration and calls the callback for every key
+	Foreach(callback KeyAction[K])
+}
+
+// KeyAction defines function prototype that used by an iteration method to iterate over tree keys
+type KeyAction[K any] func(K)
+
+// Iterator is a tree keys iterator.
+type Iterator[K any] interface {
+	// Next advances the iterator to the next key. It returns false when there are no more keys
+	Next() bool
+
+	// Current gets current key
+	Current() K
+}
@@ -0,0 +1,293 @@
+package compact
+
+import "cmp"
+
+// This file contains all compact RB tree iteration methods implementations
+
+type enumerable[K cmp.Ordered] struct{ it Iterator[K] }
+
+type iterator[K cmp.Ordered] struct {
+	enumerable[K]
+	tree *Tree[K]
+	curr uint32
+}
+
+type walk[K cmp.Ordered] struct {
+	iterator[K]
+	stack []uint32
+}
+
+type walkPreorder[K cmp.Ordered] struct{ walk[K] }
+
+type walkLevelOrder[K cmp.Ordered] struct{ walk[K] }
+
+type walkInorder[K cmp.Ordered] struct {
+	walk[K]
+	p uint32
+}
+
+type walkPostorder[K cmp.Ordered] struct {
+	walk[K]
+	p uint32
+}
+
+type ascend[K cmp.Ordered] struct{ ordered[K] }
+
+type descend[K cmp.Ordered] struct{ ordered[K] }
+
+type ordered[K cmp.Ordered] struct {
+	iterator[K]
+	next uint32
+	to   K
+}
+
+// NewWalkInorder creates Enumerable that walks tree inorder (left, node, right)
+func NewWalkInorder[K cmp.Ordered](t *Tree[K]) Enumerable[K] {
+	e := &walkInorder[K]{
+		walk: newWalk(t),
+	}
+
+	if len(e.stack) > 0 {
+		e.p = e.stack[0]
+	}
+
+	e.it = e
+	return e
+}
+
+// NewWalkPreorder creates Enumerable that walks tree preorder (node, left, right)
+func NewWalkPreorder[K cmp.Ordered](t *Tree[K]) Enumerable[K] {
+	e := &walkPreorder[K]{
+		walk: newWalk(t),
+	}
+
+	e.it = e
+	return e
+}
+
+// NewWalkPostorder creates Enumerable that walks tree postorder (left, right, node)
+func NewWalkPostorder[K cmp.Ordered](t *Tree[K]) Enumerable[K] {
+	e := &walkPostorder[K]{
+		walk: newWalk(t),
+	}
+
+	e.it = e
+	return e
+}
+
+// NewWalkLevelOrder creates Enumerable that walks tree in level order (breadth-first)
+// i.e. all nodes of the depth before all nodes of the next depth from left to right
+func NewWalkLevelOrder[K cmp.Ordered](t *Tree[K]) Enumerable[K] {
+	e := &walkLevelOrder[K]{
+		walk: newWalk(t),
+	}
+
+	e.it = e
+	return e
+}
+
+// NewAscend creates Enumerable that walks tree in ascending order
+func NewAscend[K cmp.Ordered](t *Tree[K]) Enumerable[K] {
+	return NewWalkInorder(t)
+}
+
+// NewAscendRange creates Enumerable that walks tree in ascending order within the range [from, to].
+// Unlike rbtree.NewAscendRange both ends not necessary present in the tree
+func NewAscendRange[K cmp.Ordered](t *Tree[K], from, to K) Enumerable[K] {
+	e := newAscend(t)
+
+	e.next, _ = t.lowerBound(from)
+	e.to = to
+
+	return e
+}
+
+// NewEqualRange creates Enumerable that walks all keys equal to value specified
+func NewEqualRange[K cmp.Ordered](t *Tree[K], value K) Enumerable[K] {
+	e := newAscend(t)
+
+	e.next, _ = t.search(value)
+	e.to = value
+
+	return e
+}
+
+// NewDescend creates Enumerable that walks tree in descending order
+func NewDescend[K cmp.Ordered](t *Tree[K]) Enumerable[K] {
+	e := newDescend(t)
+
+	if t.root != nilIndex {
+		e.next = t.maximum(t.root)
+		e.to = t.key(t.minimum(t.root))
+	}
+
+	return e
+}
+
+// NewDescendRange that walks tree in descending order within the range [to, from].
+// Unlike rbtree.NewDescendRange both ends not necessary present in the tree
+func NewDescendRange[K cmp.Ordered](t *Tree[K], from, to K) Enumerable[K] {
+	e := newDescend(t)
+
+	upper, _ := t.upperBound(from)
+	if upper == nilIndex {
+		e.next = t.maximum(t.root)
+	} else {
+		e.next = t.predecessor(upper)
+	}
+	e.to = to
+
+	return e
+}
+
+func (i *walkInorder[K]) Next() bool {
+	nodes := i.tree.nodes
+	for len(i.stack) > 0 {
+		if i.p != nilIndex {
+			i.p = nodes[i.p].left
+			if i.p != nilIndex {
+				i.push(i.p)
+			}
+		} else {
+			i.p = i.pop()
+			i.curr = i.p
+			i.p = nodes[i.p].right
+
+			if i.p != nilIndex {
+				i.push(i.p)
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+func (i *walkPreorder[K]) Next() bool {
+	if len(i.stack) > 0 {
+		p := i.pop()
+		i.curr = p
+
+		if r := i.tree.nodes[p].right; r != nilIndex {
+			i.push(r)
+		}
+
+		if l := i.tree.nodes[p].left; l != nilIndex {
+			i.push(l)
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func (i *walkPostorder[K]) Next() bool {
+	nodes := i.tree.nodes
+	for len(i.stack) > 0 {
+		next := i.stack[len(i.stack)-1]
+		l := nodes[next].left
+		r := nodes[next].right
+
+		if (i.p != nilIndex && (r == i.p || l == i.p)) || (r == nilIndex && l == nilIndex) {
+			i.pop()
+			i.curr = next
+			i.p = next
+			return true
+		}
+
+		if r != nilIndex {
+			i.push(r)
+		}
+		if l != nilIndex {
+			i.push(l)
+		}
+	}
+
+	return false
+}
+
+func (i *walkLevelOrder[K]) Next() bool {
+	if len(i.stack) > 0 {
+		p := i.stack[0]
+		i.stack = i.stack[1:]
+		i.curr = p
+
+		if l := i.tree.nodes[p].left; l != nilIndex {
+			i.push(l)
+		}
+
+		if r := i.tree.nodes[p].right; r != nilIndex {
+			i.push(r)
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func (i *ascend[K]) Next() bool {
+	result := i.next != nilIndex && !cmp.Less(i.to, i.tree.key(i.next))
+	if result {
+		i.curr = i.next
+		i.next = i.tree.successor(i.curr)
+	}
+	return result
+}
+
+func (i *descend[K]) Next() bool {
+	result := i.next != nilIndex && !cmp.Less(i.tree.key(i.next), i.to)
+	if result {
+		i.curr = i.next
+		i.next = i.tree.predecessor(i.curr)
+	}
+	return result
+}
+
+// Foreach does tree iteration and calls the callback for
+// every key in the tree.
+func (e *enumerable[K]) Foreach(callback KeyAction[K]) {
+	for e.it.Next() {
+		callback(e.it.Current())
+	}
+}
+
+func (e *enumerable[K]) Iterator() Iterator[K] { return e.it }
+
+func (i *iterator[K]) Current() K { return i.tree.key(i.curr) }
+
+func (w *walk[K]) push(n uint32) {
+	w.stack = append(w.stack, n)
+}
+
+func (w *walk[K]) pop() uint32 {
+	top := len(w.stack) - 1
+	p := w.stack[top]
+	w.stack = w.stack[:top]
+	return p
+}
+
+func newWalk[K cmp.Ordered](t *Tree[K]) walk[K] {
+	stack := make([]uint32, 0)
+	if t.root != nilIndex {
+		stack = append(stack, t.root)
+	}
+
+	return walk[K]{
+		iterator: iterator[K]{tree: t},
+		stack:    stack,
+	}
+}
+
+func newAscend[K cmp.Ordered](t *Tree[K]) *ascend[K] {
+	e := &ascend[K]{ordered: ordered[K]{iterator: iterator[K]{tree: t}}}
+	e.it = e
+	return e
+}
+
+func newDescend[K cmp.Ordered](t *Tree[K]) *descend[K] {
+	e := &descend[K]{ordered: ordered[K]{iterator: iterator[K]{tree: t}}}
+	e.it = e
+	return e
+}
@@ -0,0 +1,141 @@
+package compact
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_Walks(t *testing.T) {
+	var tests = []struct {
+		name     string
+		walk     func(*Tree[int]) Enumerable[int]
+		expected []int
+	}{
+		{"inorder", NewWalkInorder[int], []int{2, 3, 4, 6, 7, 9, 13, 15, 17, 18, 20}},
+		{"preorder", NewWalkPreorder[int], []int{6, 3, 2, 4, 15, 9, 7, 13, 18, 17, 20}},
+		{"postorder", NewWalkPostorder[int], []int{2, 4, 3, 7, 13, 9, 17, 20, 18, 15, 6}},
+		{"levelorder", NewWalkLevelOrder[int], []int{6, 3, 15, 2, 4, 9, 18, 7, 13, 17, 20}},
+		{"ascend", NewAscend[int], []int{2, 3, 4, 6, 7, 9, 13, 15, 17, 18, 20}},
+		{"descend", NewDescend[int], []int{20, 18, 17, 15, 13, 9, 7, 6, 4, 3, 2}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			tree := newIntTestTree()
+
+			// Act
+			result := collect(test.walk(tree))
+
+			// Assert
+			ass.Equal(test.expected, result)
+		})
+	}
+}
+
+func Test_Walks_EmptyTree_NothingIterated(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New[int]()
+
+	// Act
+	result := [][]int{
+		collect(NewWalkInorder(tree)),
+		collect(NewWalkPreorder(tree)),
+		collect(NewWalkPostorder(tree)),
+		collect(NewWalkLevelOrder(tree)),
+		collect(NewDescend(tree)),
+		collect(NewAscendRange(tree, 1, 10)),
+		collect(NewDescendRange(tree, 10, 1)),
+		collect(NewEqualRange(tree, 1)),
+	}
+
+	// Assert
+	for _, r := range result {
+		ass.Empty(r)
+	}
+}
+
+func Test_AscendRange(t *testing.T) {
+	var tests = []struct {
+		from     int
+		to       int
+		expected []int
+	}{
+		{6, 15, []int{6, 7, 9, 13, 15}},
+		{5, 14, []int{6, 7, 9, 13}},
+		{-10, 3, []int{2, 3}},
+		{19, 100, []int{20}},
+		{21, 100, nil},
+		{10, 5, nil},
+	}
+	for _, test := range tests {
+		// Arrange
+		ass := assert.New(t)
+		tree := newIntTestTree()
+
+		// Act
+		result := collect(NewAscendRange(tree, test.from, test.to))
+
+		// Assert
+		ass.Equal(test.expected, result, "[%d, %d]", test.from, test.to)
+	}
+}
+
+func Test_DescendRange(t *testing.T) {
+	var tests = []struct {
+		from     int
+		to       int
+		expected []int
+	}{
+		{15, 6, []int{15, 13, 9, 7, 6}},
+		{14, 5, []int{13, 9, 7, 6}},
+		{100, 19, []int{20}},
+		{3, -10, []int{3, 2}},
+		{1, -10, nil},
+		{5, 10, nil},
+	}
+	for _, test := range tests {
+		// Arrange
+		ass := assert.New(t)
+		tree := newIntTestTree()
+
+		// Act
+		result := collect(NewDescendRange(tree, test.from, test.to))
+
+		// Assert
+		ass.Equal(test.expected, result, "[%d, %d]", test.from, test.to)
+	}
+}
+
+func Test_EqualRange_AllDuplicatesIterated(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New[string]()
+	for _, k := range []string{"b", "a", "b", "c", "b"} {
+		tree.Insert(k)
+	}
+
+	// Act
+	result := collect(NewEqualRange(tree, "b"))
+
+	// Assert
+	ass.Equal([]string{"b", "b", "b"}, result)
+}
+
+func Test_Iterator_CurrentAfterNext(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTestTree()
+	it := NewAscendRange(tree, 17, 18).Iterator()
+	var result []int
+
+	// Act
+	for it.Next() {
+		result = append(result, it.Current())
+	}
+
+	// Assert
+	ass.Equal([]int{17, 18}, result)
+	ass.False(it.Next())
+}
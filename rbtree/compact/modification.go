@@ -0,0 +1,296 @@
+package compact
+
+import "cmp"
+
+// This file contains all compact RB tree modification methods implementations
+
+// Insert inserts new node into Red-Black tree. Creates Root if tree is empty
+func (t *Tree[K]) Insert(k K) {
+	z := t.allocate(k)
+	y := uint32(nilIndex)
+	x := t.root
+	for x != nilIndex {
+		y = x
+		t.setSize(y, t.size(y)+1)
+		if cmp.Less(k, t.nodes[x].key) {
+			x = t.nodes[x].left
+		} else {
+			x = t.nodes[x].right
+		}
+	}
+
+	t.nodes[z].parent = y
+	if y == nilIndex {
+		t.root = z
+	} else if cmp.Less(k, t.nodes[y].key) {
+		t.nodes[y].left = z
+	} else {
+		t.nodes[y].right = z
+	}
+	t.insertFixup(z)
+}
+
+// ReplaceOrInsert inserts new node into Red-Black tree. Creates Root if tree is empty
+// If a key in the tree already equals the given one, it is replaced and returned with true.
+// Otherwise, zero key and false are returned.
+func (t *Tree[K]) ReplaceOrInsert(k K) (K, bool) {
+	i, _ := t.lowerBound(k)
+	if i != nilIndex && cmp.Compare(t.nodes[i].key, k) == 0 {
+		old := t.nodes[i].key
+		t.nodes[i].key = k
+		return old, true
+	}
+	t.Insert(k)
+	var zero K
+	return zero, false
+}
+
+// Delete searches and deletes first found node with key value specified from Red-black tree
+// It returns true if node was successfully deleted otherwise false
+func (t *Tree[K]) Delete(k K) bool {
+	i, ok := t.search(k)
+	if ok {
+		t.delete(i)
+	}
+	return ok
+}
+
+// DeleteAll searches and deletes all found nodes with key value specified from Red-black tree
+// It returns true if nodes was successfully deleted otherwise false
+func (t *Tree[K]) DeleteAll(k K) bool {
+	ok := t.Delete(k)
+	res := ok
+	for ok {
+		ok = t.Delete(k)
+	}
+	return res
+}
+
+func (t *Tree[K]) allocate(k K) uint32 {
+	n := node[K]{key: k, sizeColor: 1 | redBit}
+	if t.free != nilIndex {
+		i := t.free
+		t.free = t.nodes[i].left
+		t.nodes[i] = n
+		return i
+	}
+	// slot 0 is the sentinel so the new node index equals current length
+	if len(t.nodes) > maxNodes {
+		panic(ErrCapacityExceeded)
+	}
+	t.nodes = append(t.nodes, n)
+	return uint32(len(t.nodes) - 1)
+}
+
+func (t *Tree[K]) release(i uint32) {
+	t.nodes[i] = node[K]{left: t.free}
+	t.free = i
+}
+
+func (t *Tree[K]) insertFixup(z uint32) {
+	for t.isRed(t.nodes[z].parent) {
+		p := t.nodes[z].parent
+		g := t.nodes[p].parent
+		if p == t.nodes[g].left {
+			y := t.nodes[g].right
+			if t.isRed(y) {
+				t.setRed(p, false)
+				t.setRed(y, false)
+				t.setRed(g, true)
+				z = g
+			} else {
+				if z == t.nodes[p].right {
+					z = p
+					t.leftRotate(z)
+				}
+				p = t.nodes[z].parent
+				g = t.nodes[p].parent
+				t.setRed(p, false)
+				t.setRed(g, true)
+				t.rightRotate(g)
+			}
+		} else {
+			y := t.nodes[g].left
+			if t.isRed(y) {
+				t.setRed(p, false)
+				t.setRed(y, false)
+				t.setRed(g, true)
+				z = g
+			} else {
+				if z == t.nodes[p].left {
+					z = p
+					t.rightRotate(z)
+				}
+				p = t.nodes[z].parent
+				g = t.nodes[p].parent
+				t.setRed(p, false)
+				t.setRed(g, true)
+				t.leftRotate(g)
+			}
+		}
+	}
+	t.setRed(t.root, false)
+}
+
+func (t *Tree[K]) delete(z uint32) {
+	y := z
+	var x uint32
+	yOriginalRed := t.isRed(y)
+	if t.nodes[z].left == nilIndex {
+		t.decrementSizes(t.nodes[z].parent)
+		x = t.nodes[z].right
+		t.transplant(z, x)
+	} else if t.nodes[z].right == nilIndex {
+		t.decrementSizes(t.nodes[z].parent)
+		x = t.nodes[z].left
+		t.transplant(z, x)
+	} else {
+		y = t.minimum(t.nodes[z].right)
+		// y is removed from its place so all nodes from y's parent up to the root lose one node
+		t.decrementSizes(t.nodes[y].parent)
+		yOriginalRed = t.isRed(y)
+		x = t.nodes[y].right
+		if t.nodes[y].parent == z {
+			t.nodes[x].parent = y
+		} else {
+			t.transplant(y, x)
+			t.nodes[y].right = t.nodes[z].right
+			t.nodes[t.nodes[y].right].parent = y
+		}
+		t.transplant(z, y)
+		t.nodes[y].left = t.nodes[z].left
+		t.nodes[t.nodes[y].left].parent = y
+		t.nodes[y].sizeColor = t.nodes[z].sizeColor
+	}
+	if !yOriginalRed {
+		t.deleteFixup(x)
+	}
+	// sentinel parent may be changed by transplant
+	t.nodes[nilIndex].parent = nilIndex
+	t.release(z)
+}
+
+func (t *Tree[K]) decrementSizes(p uint32) {
+	for p != nilIndex {
+		t.setSize(p, t.size(p)-1)
+		p = t.nodes[p].parent
+	}
+}
+
+func (t *Tree[K]) deleteFixup(x uint32) {
+	for x != t.root && !t.isRed(x) {
+		p := t.nodes[x].parent
+		if x == t.nodes[p].left {
+			w := t.nodes[p].right
+			if t.isRed(w) {
+				t.setRed(w, false)
+				t.setRed(p, true)
+				t.leftRotate(p)
+				w = t.nodes[p].right
+			}
+
+			if !t.isRed(t.nodes[w].left) && !t.isRed(t.nodes[w].right) {
+				t.setRed(w, true)
+				x = p
+			} else {
+				if !t.isRed(t.nodes[w].right) {
+					t.setRed(t.nodes[w].left, false)
+					t.setRed(w, true)
+					t.rightRotate(w)
+					w = t.nodes[p].right
+				}
+
+				t.setRed(w, t.isRed(p))
+				t.setRed(p, false)
+				t.setRed(t.nodes[w].right, false)
+				t.leftRotate(p)
+				x = t.root
+			}
+		} else {
+			w := t.nodes[p].left
+			if t.isRed(w) {
+				t.setRed(w, false)
+				t.setRed(p, true)
+				t.rightRotate(p)
+				w = t.nodes[p].left
+			}
+
+			if !t.isRed(t.nodes[w].right) && !t.isRed(t.nodes[w].left) {
+				t.setRed(w, true)
+				x = p
+			} else {
+				if !t.isRed(t.nodes[w].left) {
+					t.setRed(t.nodes[w].right, false)
+					t.setRed(w, true)
+					t.leftRotate(w)
+					w = t.nodes[p].left
+				}
+
+				t.setRed(w, t.isRed(p))
+				t.setRed(p, false)
+				t.setRed(t.nodes[w].left, false)
+				t.rightRotate(p)
+				x = t.root
+			}
+		}
+	}
+	t.setRed(x, false)
+}
+
+func (t *Tree[K]) transplant(u uint32, v uint32) {
+	p := t.nodes[u].parent
+	if p == nilIndex {
+		t.root = v
+	} else if u == t.nodes[p].left {
+		t.nodes[p].left = v
+	} else {
+		t.nodes[p].right = v
+	}
+	t.nodes[v].parent = p
+}
+
+func (t *Tree[K]) leftRotate(x uint32) {
+	y := t.nodes[x].right
+	t.nodes[x].right = t.nodes[y].left
+	if t.nodes[y].left != nilIndex {
+		t.nodes[t.nodes[y].left].parent = x
+	}
+	p := t.nodes[x].parent
+	t.nodes[y].parent = p
+	if p == nilIndex {
+		t.root = y
+	} else if x == t.nodes[p].left {
+		t.nodes[p].left = y
+	} else {
+		t.nodes[p].right = y
+	}
+
+	t.nodes[y].left = x
+	t.nodes[x].parent = y
+
+	t.setSize(y, t.size(x))
+	t.setSize(x, t.size(t.nodes[x].left)+t.size(t.nodes[x].right)+1)
+}
+
+func (t *Tree[K]) rightRotate(x uint32) {
+	y := t.nodes[x].left
+	t.nodes[x].left = t.nodes[y].right
+	if t.nodes[y].right != nilIndex {
+		t.nodes[t.nodes[y].right].parent = x
+	}
+	p := t.nodes[x].parent
+	t.nodes[y].parent = p
+	if p == nilIndex {
+		t.root = y
+	} else if x == t.nodes[p].right {
+		t.nodes[p].right = y
+	} else {
+		t.nodes[p].left = y
+	}
+
+	t.nodes[y].right = x
+	t.nodes[x].parent = y
+
+	t.setSize(y, t.size(x))
+	t.setSize(x, t.size(t.nodes[x].left)+t.size(t.nodes[x].right)+1)
+}
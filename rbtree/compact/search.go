@@ -0,0 +1,138 @@
+package compact
+
+import "cmp"
+
+// This file contains all compact RB tree search methods implementations
+
+// Search searches value specified within search tree
+func (t *Tree[K]) Search(value K) (K, bool) {
+	i, ok := t.search(value)
+	return t.key(i), ok
+}
+
+// Floor searches the greatest key lesser than or equal to value specified.
+// It returns false if all keys are greater than value
+func (t *Tree[K]) Floor(value K) (K, bool) {
+	var result uint32
+	x := t.root
+	for x != nilIndex {
+		if cmp.Less(value, t.nodes[x].key) {
+			x = t.nodes[x].left
+		} else {
+			result = x
+			x = t.nodes[x].right
+		}
+	}
+	return t.key(result), result != nilIndex
+}
+
+// Ceiling searches the smallest key larger than or equal to value specified.
+// It returns false if all keys are less than value
+func (t *Tree[K]) Ceiling(value K) (K, bool) {
+	i, _ := t.lowerBound(value)
+	return t.key(i), i != nilIndex
+}
+
+// EqualRange gets ranks of the first and the last keys equal to value specified
+// and the number of such keys. It runs in O(log n). If nothing found all results are zero
+func (t *Tree[K]) EqualRange(value K) (first int64, last int64, count int64) {
+	i, before := t.lowerBound(value)
+	if i == nilIndex || cmp.Compare(t.nodes[i].key, value) != 0 {
+		return 0, 0, 0
+	}
+	_, through := t.upperBound(value)
+	return before + 1, through, through - before
+}
+
+// Rank gets the rank (starting from 1) of the first key equal to value specified
+func (t *Tree[K]) Rank(value K) (int64, bool) {
+	first, _, count := t.EqualRange(value)
+	return first, count > 0
+}
+
+// Minimum gets tree's min key
+func (t *Tree[K]) Minimum() (K, bool) {
+	if t.root == nilIndex {
+		return t.key(nilIndex), false
+	}
+	return t.key(t.minimum(t.root)), true
+}
+
+// Maximum gets tree's max key
+func (t *Tree[K]) Maximum() (K, bool) {
+	if t.root == nilIndex {
+		return t.key(nilIndex), false
+	}
+	return t.key(t.maximum(t.root)), true
+}
+
+// OrderStatisticSelect gets i key from the tree
+// IMPORTANT: numeration starts from 1 not from 0
+func (t *Tree[K]) OrderStatisticSelect(i int64) (K, bool) {
+	x := t.selectNode(i)
+	return t.key(x), x != nilIndex
+}
+
+func (t *Tree[K]) selectNode(i int64) uint32 {
+	if i < 1 || i > t.Len() {
+		return nilIndex
+	}
+	x := t.root
+	for {
+		r := int64(t.size(t.nodes[x].left)) + 1
+		if i == r {
+			return x
+		}
+		if i < r {
+			x = t.nodes[x].left
+		} else {
+			i -= r
+			x = t.nodes[x].right
+		}
+	}
+}
+
+// search finds the first node which key is equal to value
+func (t *Tree[K]) search(value K) (uint32, bool) {
+	i, _ := t.lowerBound(value)
+	if i == nilIndex || cmp.Compare(t.nodes[i].key, value) != 0 {
+		return nilIndex, false
+	}
+	return i, true
+}
+
+// lowerBound finds the first node which key isn't less than value
+// and the number of nodes before it
+func (t *Tree[K]) lowerBound(value K) (uint32, int64) {
+	var result uint32
+	var before int64
+	x := t.root
+	for x != nilIndex {
+		if cmp.Less(t.nodes[x].key, value) {
+			before += int64(t.size(t.nodes[x].left)) + 1
+			x = t.nodes[x].right
+		} else {
+			result = x
+			x = t.nodes[x].left
+		}
+	}
+	return result, before
+}
+
+// upperBound finds the first node which key is greater than value
+// and the number of nodes before it
+func (t *Tree[K]) upperBound(value K) (uint32, int64) {
+	var result uint32
+	var before int64
+	x := t.root
+	for x != nilIndex {
+		if cmp.Less(value, t.nodes[x].key) {
+			result = x
+			x = t.nodes[x].left
+		} else {
+			before += int64(t.size(t.nodes[x].left)) + 1
+			x = t.nodes[x].right
+		}
+	}
+	return result, before
+}
@@ -0,0 +1,126 @@
+package compact
+
+import (
+	"cmp"
+	"errors"
+	"unsafe"
+)
+
+// ErrCapacityExceeded is the panic value when inserting into the tree that already holds maxNodes nodes
+var ErrCapacityExceeded = errors.New("compact tree capacity of 2^31-1 nodes exceeded")
+
+// nilIndex is the index of the sentinel node that plays the role of all leaves and the root's parent
+const nilIndex = 0
+
+// redBit marks red node within node's sizeColor
+const redBit = 1 << 31
+
+// sizeMask extracts subtree size from node's sizeColor
+const sizeMask = redBit - 1
+
+// maxNodes is the maximal number of nodes. Both node index and subtree size must fit into 31 bits
+const maxNodes = sizeMask
+
+// Tree is the index-based Red-black tree. Its capacity is limited by 2^31-1 nodes,
+// Insert panics with ErrCapacityExceeded beyond it. Deleted nodes slots are reused by subsequent inserts
+type Tree[K cmp.Ordered] struct {
+	nodes []node[K]
+	root  uint32
+
+	// free is the head of deleted slots list linked by left index
+	free uint32
+}
+
+type node[K cmp.Ordered] struct {
+	key    K
+	left   uint32
+	right  uint32
+	parent uint32
+
+	// Subtree size including node itself in lower 31 bits and red color in the highest bit
+	sizeColor uint32
+}
+
+// New creates new empty compact Red-Black tree
+func New[K cmp.Ordered]() *Tree[K] {
+	return NewWithCapacity[K](0)
+}
+
+// NewWithCapacity creates new empty compact Red-Black tree that preallocates space for capacity nodes
+func NewWithCapacity[K cmp.Ordered](capacity int) *Tree[K] {
+	nodes := make([]node[K], 1, capacity+1)
+	return &Tree[K]{nodes: nodes}
+}
+
+// Len returns the number of nodes in the tree.
+func (t *Tree[K]) Len() int64 {
+	return int64(t.size(t.root))
+}
+
+// MemoryBytes gets the number of bytes allocated for tree nodes
+// not including memory referenced by keys (like strings content)
+func (t *Tree[K]) MemoryBytes() int64 {
+	return int64(cap(t.nodes)) * int64(unsafe.Sizeof(node[K]{}))
+}
+
+func (t *Tree[K]) size(i uint32) uint32 {
+	return t.nodes[i].sizeColor & sizeMask
+}
+
+func (t *Tree[K]) setSize(i uint32, size uint32) {
+	t.nodes[i].sizeColor = t.nodes[i].sizeColor&redBit | size
+}
+
+func (t *Tree[K]) isRed(i uint32) bool {
+	return t.nodes[i].sizeColor&redBit != 0
+}
+
+func (t *Tree[K]) setRed(i uint32, red bool) {
+	if red {
+		t.nodes[i].sizeColor |= redBit
+	} else {
+		t.nodes[i].sizeColor &= sizeMask
+	}
+}
+
+func (t *Tree[K]) key(i uint32) K {
+	return t.nodes[i].key
+}
+
+func (t *Tree[K]) minimum(i uint32) uint32 {
+	for t.nodes[i].left != nilIndex {
+		i = t.nodes[i].left
+	}
+	return i
+}
+
+func (t *Tree[K]) maximum(i uint32) uint32 {
+	for t.nodes[i].right != nilIndex {
+		i = t.nodes[i].right
+	}
+	return i
+}
+
+func (t *Tree[K]) successor(i uint32) uint32 {
+	if t.nodes[i].right != nilIndex {
+		return t.minimum(t.nodes[i].right)
+	}
+	p := t.nodes[i].parent
+	for p != nilIndex && i == t.nodes[p].right {
+		i = p
+		p = t.nodes[p].parent
+	}
+	return p
+}
+
+func (t *Tree[K]) predecessor(i uint32) uint32 {
+	if t.nodes[i].left != nilIndex {
+		return t.maximum(t.nodes[i].left)
+	}
+	p := t.nodes[i].parent
+	for p != nilIndex && i == t.nodes[p].left {
+		i = p
+		p = t.nodes[p].parent
+	}
+	return p
+}
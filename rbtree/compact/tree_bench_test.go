@@ -0,0 +1,126 @@
+package compact
+
+import (
+	"github.com/aegoroff/godatastruct/rbtree"
+	"math/rand"
+	"testing"
+)
+
+const treeSizeInsert = 20000
+const treeSizeSearchOrIterate = 50000
+const searches = 64
+
+func Benchmark_CompactTree_Insert(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		tree := New[int]()
+		ints := rand.Perm(treeSizeInsert)
+		b.StartTimer()
+
+		for _, n := range ints {
+			tree.Insert(n)
+		}
+	}
+	b.ReportAllocs()
+}
+
+func Benchmark_RbTree_Insert(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		tree := rbtree.New()
+		ints := rand.Perm(treeSizeInsert)
+		b.StartTimer()
+
+		for _, n := range ints {
+			tree.Insert(rbtree.Int(n))
+		}
+	}
+	b.ReportAllocs()
+}
+
+func Benchmark_CompactTree_Search(b *testing.B) {
+	// Arrange
+	tree := New[int]()
+	for _, n := range rand.Perm(treeSizeSearchOrIterate) {
+		tree.Insert(n * 2)
+	}
+	off := rand.Intn(treeSizeSearchOrIterate / 2)
+
+	// Act
+	for i := 0; i < b.N; i++ {
+		for j := range searches {
+			tree.Search((j + off) * 2)
+			tree.Search((j+off)*2 + 1)
+		}
+	}
+	b.ReportAllocs()
+}
+
+func Benchmark_RbTree_Search(b *testing.B) {
+	// Arrange
+	tree := rbtree.New()
+	for _, n := range rand.Perm(treeSizeSearchOrIterate) {
+		tree.Insert(rbtree.Int(n * 2))
+	}
+	off := rand.Intn(treeSizeSearchOrIterate / 2)
+
+	// Act
+	for i := 0; i < b.N; i++ {
+		for j := range searches {
+			tree.Search(rbtree.Int((j + off) * 2))
+			tree.Search(rbtree.Int((j+off)*2 + 1))
+		}
+	}
+	b.ReportAllocs()
+}
+
+func Benchmark_CompactTree_Ascend(b *testing.B) {
+	// Arrange
+	tree := New[int]()
+	for _, n := range rand.Perm(treeSizeSearchOrIterate) {
+		tree.Insert(n)
+	}
+
+	// Act
+	for i := 0; i < b.N; i++ {
+		NewAscend(tree).Foreach(func(int) {})
+	}
+	b.ReportAllocs()
+}
+
+func Benchmark_RbTree_Ascend(b *testing.B) {
+	// Arrange
+	tree := rbtree.New()
+	for _, n := range rand.Perm(treeSizeSearchOrIterate) {
+		tree.Insert(rbtree.Int(n))
+	}
+
+	// Act
+	for i := 0; i < b.N; i++ {
+		rbtree.NewAscend(tree).Foreach(func(rbtree.Comparable) {})
+	}
+	b.ReportAllocs()
+}
+
+// Benchmark_CompactTree_Memory reports bytes used by tree nodes per key
+func Benchmark_CompactTree_Memory(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		tree := New[int]()
+		for n := range treeSizeInsert {
+			tree.Insert(n)
+		}
+		b.ReportMetric(float64(tree.MemoryBytes())/treeSizeInsert, "bytes/key")
+	}
+	b.ReportAllocs()
+}
+
+// Benchmark_RbTree_Memory reports bytes allocated per key
+func Benchmark_RbTree_Memory(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		tree := rbtree.New()
+		for n := range treeSizeInsert {
+			tree.Insert(rbtree.Int(n))
+		}
+	}
+	b.ReportAllocs()
+}
@@ -0,0 +1,335 @@
+package compact
+
+import (
+	"cmp"
+	"github.com/aegoroff/godatastruct/rbtree"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func Test_InsertAndLen(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New[int]()
+
+	// Act
+	for _, k := range []int{6, 18, 3, 15, 7, 2, 4, 13, 9, 17, 20} {
+		tree.Insert(k)
+	}
+
+	// Assert
+	ass.Equal(int64(11), tree.Len())
+	ass.True(validTree(tree))
+}
+
+func Test_EmptyTree_NothingFound(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New[string]()
+
+	// Act
+	_, found := tree.Search("a")
+	_, floor := tree.Floor("a")
+	_, ceiling := tree.Ceiling("a")
+	_, min := tree.Minimum()
+	_, max := tree.Maximum()
+	_, sel := tree.OrderStatisticSelect(1)
+	_, rank := tree.Rank("a")
+
+	// Assert
+	ass.Equal(int64(0), tree.Len())
+	ass.False(found)
+	ass.False(floor)
+	ass.False(ceiling)
+	ass.False(min)
+	ass.False(max)
+	ass.False(sel)
+	ass.False(rank)
+	ass.False(tree.Delete("a"))
+}
+
+func Test_FloorCeiling(t *testing.T) {
+	var tests = []struct {
+		x       int
+		floor   int
+		floorOk bool
+		ceiling int
+		ceilOk  bool
+	}{
+		{1, 0, false, 2, true},
+		{2, 2, true, 2, true},
+		{5, 4, true, 6, true},
+		{20, 20, true, 20, true},
+		{21, 20, true, 0, false},
+	}
+	for _, test := range tests {
+		// Arrange
+		ass := assert.New(t)
+		tree := newIntTestTree()
+
+		// Act
+		floor, floorOk := tree.Floor(test.x)
+		ceiling, ceilOk := tree.Ceiling(test.x)
+
+		// Assert
+		ass.Equal(test.floorOk, floorOk, "x = %d", test.x)
+		ass.Equal(test.floor, floor, "x = %d", test.x)
+		ass.Equal(test.ceilOk, ceilOk, "x = %d", test.x)
+		ass.Equal(test.ceiling, ceiling, "x = %d", test.x)
+	}
+}
+
+func Test_Duplicates_EqualRangeAndDeleteAll(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New[int]()
+	for _, k := range []int{5, 1, 5, 3, 5, 7} {
+		tree.Insert(k)
+	}
+
+	// Act
+	first, last, count := tree.EqualRange(5)
+	deleted := tree.DeleteAll(5)
+
+	// Assert
+	ass.Equal(int64(3), first)
+	ass.Equal(int64(5), last)
+	ass.Equal(int64(3), count)
+	ass.True(deleted)
+	ass.Equal(int64(3), tree.Len())
+	ass.True(validTree(tree))
+}
+
+func Test_ReplaceOrInsert(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New[float64]()
+	tree.Insert(1)
+
+	// Act
+	_, replaced1 := tree.ReplaceOrInsert(2)
+	old, replaced2 := tree.ReplaceOrInsert(1)
+
+	// Assert
+	ass.False(replaced1)
+	ass.True(replaced2)
+	ass.Equal(1.0, old)
+	ass.Equal(int64(2), tree.Len())
+}
+
+func Test_NaNKeys_OrderedFirst(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New[float64]()
+	tree.Insert(1)
+	tree.Insert(math.NaN())
+	tree.Insert(-1)
+
+	// Act
+	min, _ := tree.Minimum()
+	_, found := tree.Search(math.NaN())
+
+	// Assert
+	ass.True(math.IsNaN(min))
+	ass.True(found)
+}
+
+func Test_DeleteReusesSlots_MemoryNotGrown(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := NewWithCapacity[int](100)
+	for i := 0; i < 100; i++ {
+		tree.Insert(i)
+	}
+	memory := tree.MemoryBytes()
+
+	// Act
+	for i := 0; i < 50; i++ {
+		tree.Delete(i * 2)
+	}
+	for i := 0; i < 50; i++ {
+		tree.Insert(i * 2)
+	}
+
+	// Assert
+	ass.Equal(memory, tree.MemoryBytes())
+	ass.Equal(int64(100), tree.Len())
+	ass.True(validTree(tree))
+}
+
+func Test_RandomInsertDelete_EqualToSortedSlice(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	r := rand.New(rand.NewSource(1000))
+	tree := New[int]()
+	var expected []int
+
+	for i := 0; i < 3000; i++ {
+		// Act
+		k := r.Intn(500)
+		if r.Intn(3) == 0 {
+			ix := sort.SearchInts(expected, k)
+			ok := ix < len(expected) && expected[ix] == k
+			if ok {
+				expected = append(expected[:ix], expected[ix+1:]...)
+			}
+			ass.Equal(ok, tree.Delete(k))
+		} else {
+			ix := sort.SearchInts(expected, k+1)
+			expected = append(expected[:ix], append([]int{k}, expected[ix:]...)...)
+			tree.Insert(k)
+		}
+	}
+
+	// Assert
+	ass.True(validTree(tree))
+	ass.Equal(int64(len(expected)), tree.Len())
+	ass.Equal(expected, collect(NewAscend(tree)))
+	for i := range expected {
+		k, ok := tree.OrderStatisticSelect(int64(i + 1))
+		ass.True(ok)
+		ass.Equal(expected[i], k)
+	}
+	for k := -1; k < 501; k++ {
+		ix := sort.SearchInts(expected, k)
+		rank, ok := tree.Rank(k)
+		ass.Equal(ix < len(expected) && expected[ix] == k, ok)
+		if ok {
+			ass.Equal(int64(ix+1), rank)
+		}
+	}
+}
+
+func Test_RandomOperations_EqualToRbTree(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	r := rand.New(rand.NewSource(1000))
+	tree := New[int]()
+	reference := rbtree.New()
+
+	// Act
+	for i := 0; i < 3000; i++ {
+		k := r.Intn(300)
+		switch r.Intn(4) {
+		case 0:
+			ass.Equal(reference.Delete(rbtree.Int(k)), tree.Delete(k), "delete %d", k)
+		case 1:
+			ass.Equal(reference.DeleteAll(rbtree.Int(k)), tree.DeleteAll(k), "delete all %d", k)
+		case 2:
+			expected := reference.ReplaceOrInsert(rbtree.Int(k))
+			old, ok := tree.ReplaceOrInsert(k)
+			ass.Equal(expected != nil, ok, "replace %d", k)
+			if ok {
+				ass.Equal(rbtree.GetInt(expected), old)
+			}
+		default:
+			reference.Insert(rbtree.Int(k))
+			tree.Insert(k)
+		}
+	}
+
+	// Assert
+	ass.True(validTree(tree))
+	ass.Equal(reference.Len(), tree.Len())
+	ass.Equal(collectReference(rbtree.NewAscend(reference)), collect(NewAscend(tree)))
+	ass.Equal(collectReference(rbtree.NewDescend(reference)), collect(NewDescend(tree)))
+	minimum, _ := tree.Minimum()
+	maximum, _ := tree.Maximum()
+	ass.Equal(rbtree.GetInt(reference.Minimum().Key()), minimum)
+	ass.Equal(rbtree.GetInt(reference.Maximum().Key()), maximum)
+	for i := int64(1); i <= reference.Len(); i++ {
+		expected, _ := reference.OrderStatisticSelect(i)
+		k, ok := tree.OrderStatisticSelect(i)
+		ass.True(ok)
+		ass.Equal(rbtree.GetInt(expected.Key()), k)
+	}
+	for k := minimum; k <= maximum; k++ {
+		x := rbtree.Int(k)
+		_, expectedOk := reference.Search(x)
+		_, ok := tree.Search(k)
+		ass.Equal(expectedOk, ok, "search %d", k)
+
+		expectedFloor, _ := reference.Floor(x)
+		floor, _ := tree.Floor(k)
+		ass.Equal(rbtree.GetInt(expectedFloor), floor, "floor %d", k)
+
+		expectedCeiling, _ := reference.Ceiling(x)
+		ceiling, _ := tree.Ceiling(k)
+		ass.Equal(rbtree.GetInt(expectedCeiling), ceiling, "ceiling %d", k)
+
+		expectedFirst, expectedLast, expectedCount := reference.EqualRange(x)
+		first, last, count := tree.EqualRange(k)
+		ass.Equal(expectedCount, count, "equal range %d", k)
+		if count > 0 {
+			ass.Equal(expectedFirst.Rank(), first, "equal range %d", k)
+			ass.Equal(expectedLast.Rank(), last, "equal range %d", k)
+			ass.Equal(collectReference(rbtree.NewEqualRange(reference, x)), collect(NewEqualRange(tree, k)))
+		}
+		// rbtree ranges start from any of equal keys found so compare them only for unique ones
+		if count == 1 {
+			ass.Equal(collectReference(rbtree.NewAscendRange(reference, x, rbtree.Int(k+50))), collect(NewAscendRange(tree, k, k+50)))
+			ass.Equal(collectReference(rbtree.NewDescendRange(reference, x, rbtree.Int(k-50))), collect(NewDescendRange(tree, k, k-50)))
+		}
+	}
+}
+
+func newIntTestTree() *Tree[int] {
+	tree := New[int]()
+	for _, k := range []int{6, 18, 3, 15, 7, 2, 4, 13, 9, 17, 20} {
+		tree.Insert(k)
+	}
+	return tree
+}
+
+func collect[K cmp.Ordered](e Enumerable[K]) []K {
+	var result []K
+	e.Foreach(func(k K) {
+		result = append(result, k)
+	})
+	return result
+}
+
+func collectReference(e rbtree.Enumerable) []int {
+	var result []int
+	e.Foreach(func(c rbtree.Comparable) {
+		result = append(result, rbtree.GetInt(c))
+	})
+	return result
+}
+
+// validTree checks Red-black tree properties, parent links and subtree sizes
+func validTree[K cmp.Ordered](t *Tree[K]) bool {
+	if t.isRed(t.root) || t.isRed(nilIndex) || t.nodes[t.root].parent != nilIndex {
+		return false
+	}
+	_, ok := validSubtree(t, t.root)
+	return ok
+}
+
+func validSubtree[K cmp.Ordered](t *Tree[K], i uint32) (int, bool) {
+	if i == nilIndex {
+		return 1, true
+	}
+	n := t.nodes[i]
+	if t.isRed(i) && (t.isRed(n.left) || t.isRed(n.right)) {
+		return 0, false
+	}
+	if (n.left != nilIndex && t.nodes[n.left].parent != i) || (n.right != nilIndex && t.nodes[n.right].parent != i) {
+		return 0, false
+	}
+	if t.size(i) != t.size(n.left)+t.size(n.right)+1 {
+		return 0, false
+	}
+	lh, lok := validSubtree(t, n.left)
+	rh, rok := validSubtree(t, n.right)
+	if !lok || !rok || lh != rh {
+		return 0, false
+	}
+	if !t.isRed(i) {
+		lh++
+	}
+	return lh, true
+}
@@ -0,0 +1,133 @@
+package rbtree
+
+// This file contains trees difference calculation implementation
+
+// DiffKind defines the kind of difference between two trees
+type DiffKind int
+
+const (
+	// Added means that key is present in the second tree but absent in the first one
+	Added DiffKind = iota
+
+	// Removed means that key is present in the first tree but absent in the second one
+	Removed
+)
+
+// DiffEntry represents single difference between two trees
+type DiffEntry struct {
+	// Kind is the kind of difference
+	Kind DiffKind
+
+	// Key is the key added or removed
+	Key Comparable
+}
+
+// DiffIterator is an iterator over differences between two trees
+type DiffIterator interface {
+	// Current gets current difference
+	Current() DiffEntry
+
+	// Next advances the iterator and returns whether
+	// the next call to the Current method will return
+	// valid difference.
+	Next() bool
+}
+
+type diffCursor struct {
+	// stack contains nodes which keys and right subtrees aren't visited yet
+	stack []*Node
+}
+
+type diff struct {
+	a    diffCursor
+	b    diffCursor
+	curr DiffEntry
+}
+
+// Diff creates DiffIterator that yields keys that must be removed from and added to tree a
+// to get tree b in ascending order. Duplicate keys are matched one to one.
+// Trees are walked inorder simultaneously so it runs in O(n + m). Trees cannot share nodes
+// (every node has parent link and belongs to a single tree, Clone creates new nodes) so the only
+// shortcut is the same tree passed twice that gives no differences in O(1).
+// Trees must not be modified during iteration
+func Diff(a, b RbTree) DiffIterator {
+	if a.Root() == b.Root() {
+		return &diff{}
+	}
+	return &diff{
+		a: newDiffCursor(a.Root()),
+		b: newDiffCursor(b.Root()),
+	}
+}
+
+// Equal checks whether trees specified contain the same keys
+func Equal(a, b RbTree) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	return !Diff(a, b).Next()
+}
+
+func (d *diff) Current() DiffEntry { return d.curr }
+
+func (d *diff) Next() bool {
+	for {
+		switch {
+		case d.a.empty() && d.b.empty():
+			return false
+		case d.a.empty():
+			d.curr = DiffEntry{Kind: Added, Key: d.b.pop()}
+			return true
+		case d.b.empty():
+			d.curr = DiffEntry{Kind: Removed, Key: d.a.pop()}
+			return true
+		}
+
+		ka := d.a.top().key
+		kb := d.b.top().key
+		if ka.Less(kb) {
+			d.curr = DiffEntry{Kind: Removed, Key: d.a.pop()}
+			return true
+		}
+		if kb.Less(ka) {
+			d.curr = DiffEntry{Kind: Added, Key: d.b.pop()}
+			return true
+		}
+		d.a.pop()
+		d.b.pop()
+	}
+}
+
+func newDiffCursor(root *Node) diffCursor {
+	c := diffCursor{stack: make([]*Node, 0)}
+	c.pushLeft(root)
+	return c
+}
+
+func (c *diffCursor) empty() bool { return len(c.stack) == 0 }
+
+// top gets the node with the smallest key not visited yet
+func (c *diffCursor) top() *Node { return c.stack[len(c.stack)-1] }
+
+// pop removes top node and returns its key
+func (c *diffCursor) pop() Comparable {
+	top := len(c.stack) - 1
+	n := c.stack[top]
+	c.stack = c.stack[:top]
+	c.pushLeft(n.right)
+	return n.key
+}
+
+// pushLeft pushes node specified and all its left descendants
+func (c *diffCursor) pushLeft(n *Node) {
+	for ; n.isNotNil(); n = n.left {
+		c.stack = append(c.stack, n)
+	}
+}
+
+func (k DiffKind) String() string {
+	if k == Removed {
+		return "removed"
+	}
+	return "added"
+}
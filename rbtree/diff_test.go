@@ -0,0 +1,149 @@
+package rbtree
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+type countedInt struct {
+	v     int
+	count *int
+}
+
+func (x countedInt) Less(y Comparable) bool {
+	*x.count++
+	return x.v < y.(countedInt).v
+}
+
+func (x countedInt) Equal(y Comparable) bool {
+	*x.count++
+	return x.v == y.(countedInt).v
+}
+
+func Test_Diff_EntriesAsExpected(t *testing.T) {
+	var tests = []struct {
+		name    string
+		a       []int
+		b       []int
+		added   []int
+		removed []int
+	}{
+		{"equal", []int{1, 2, 3}, []int{3, 2, 1}, []int{}, []int{}},
+		{"both empty", []int{}, []int{}, []int{}, []int{}},
+		{"first empty", []int{}, []int{1, 2}, []int{1, 2}, []int{}},
+		{"second empty", []int{1, 2}, []int{}, []int{}, []int{1, 2}},
+		{"added and removed", []int{1, 2, 3, 5}, []int{2, 4, 5, 6}, []int{4, 6}, []int{1, 3}},
+		{"duplicates", []int{1, 1, 2}, []int{1, 2, 2, 2}, []int{2, 2}, []int{1}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			a := newIntTree(test.a)
+			b := newIntTree(test.b)
+			added := make([]int, 0)
+			removed := make([]int, 0)
+
+			// Act
+			it := Diff(a, b)
+			for it.Next() {
+				e := it.Current()
+				if e.Kind == Added {
+					added = append(added, GetInt(e.Key))
+				} else {
+					removed = append(removed, GetInt(e.Key))
+				}
+			}
+
+			// Assert
+			ass.Equal(test.added, added)
+			ass.Equal(test.removed, removed)
+			ass.Equal(len(added)+len(removed) == 0, Equal(a, b))
+		})
+	}
+}
+
+func Test_DiffRandom_AppliedDiffMakesTreesEqual(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	r := rand.New(rand.NewSource(1000))
+	a := New()
+	b := New()
+	for i := 0; i < 2000; i++ {
+		a.Insert(Int(r.Intn(1000)))
+		b.Insert(Int(r.Intn(1000)))
+	}
+	entries := make([]DiffEntry, 0)
+
+	// Act
+	it := Diff(a, b)
+	for it.Next() {
+		entries = append(entries, it.Current())
+	}
+
+	// Assert
+	ass.True(sort.SliceIsSorted(entries, func(i, j int) bool { return entries[i].Key.Less(entries[j].Key) }))
+	for _, e := range entries {
+		if e.Kind == Added {
+			a.Insert(e.Key)
+		} else {
+			ass.True(a.Delete(e.Key))
+		}
+	}
+	ass.True(Equal(a, b))
+}
+
+func Test_DiffSameTree_NoKeysCompared(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	var count int
+	tree := New()
+	for i := 0; i < 1000; i++ {
+		tree.Insert(countedInt{v: i, count: &count})
+	}
+	count = 0
+
+	// Act
+	eq := Equal(tree, tree)
+
+	// Assert
+	ass.True(eq)
+	ass.Equal(0, count)
+}
+
+func Test_DiffKindString(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+
+	// Act
+	a := Added.String()
+	r := Removed.String()
+
+	// Assert
+	ass.Equal("added", a)
+	ass.Equal("removed", r)
+}
+
+func ExampleDiff() {
+	a := New()
+	b := New()
+
+	for i := 1; i <= 5; i++ {
+		a.Insert(Int(i))
+		b.Insert(Int(i + 2))
+	}
+
+	it := Diff(a, b)
+	for it.Next() {
+		e := it.Current()
+		fmt.Println(e.Kind, e.Key)
+	}
+	// Output:
+	// removed 1
+	// removed 2
+	// added 6
+	// added 7
+}
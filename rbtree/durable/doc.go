@@ -0,0 +1,6 @@
+// Package durable provides ordered set that survives process crashes. Every Insert, Delete
+// and ReplaceOrInsert is appended to write-ahead log before it is applied to in-memory Red-black tree.
+// Opening the set loads the latest snapshot and replays the log written after it.
+// Compaction writes the whole tree into a new snapshot and truncates the log.
+// Keys are serialized using pluggable rbtree.KeyCodec.
+package durable
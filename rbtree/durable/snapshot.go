@@ -0,0 +1,108 @@
+package durable
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/aegoroff/godatastruct/rbtree"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// This file contains snapshot file reading and writing
+
+// ErrCorruptedSnapshot is returned when snapshot file is malformed or its checksum doesn't match
+var ErrCorruptedSnapshot = errors.New("corrupted snapshot")
+
+const snapshotVersion = 1
+
+// snapshotHeaderSize is the size of magic, version, last applied LSN and data CRC-32C
+const snapshotHeaderSize = 4 + 4 + 8 + 4
+
+var snapshotMagic = []byte{'R', 'B', 'T', 'S'}
+
+// writeSnapshot atomically replaces snapshot file with tree content and LSN of the last applied operation.
+// Data is written into temporary file that is synced and renamed over the snapshot
+func writeSnapshot(path string, tree rbtree.RbTree, lsn uint64) error {
+	data, err := tree.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	header := make([]byte, snapshotHeaderSize)
+	copy(header, snapshotMagic)
+	binary.LittleEndian.PutUint32(header[4:], snapshotVersion)
+	binary.LittleEndian.PutUint64(header[8:], lsn)
+	binary.LittleEndian.PutUint32(header[16:], crc32.Checksum(data, castagnoli))
+
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(header, data...))
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(path))
+}
+
+// readSnapshot loads snapshot into the tree specified and returns LSN of the last operation it contains.
+// Missing snapshot is treated as empty one
+func readSnapshot(path string, tree rbtree.RbTree) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if len(data) < snapshotHeaderSize || string(data[:len(snapshotMagic)]) != string(snapshotMagic) {
+		return 0, fmt.Errorf("%w: bad header", ErrCorruptedSnapshot)
+	}
+	if v := binary.LittleEndian.Uint32(data[4:]); v != snapshotVersion {
+		return 0, fmt.Errorf("%w: unsupported version %d", ErrCorruptedSnapshot, v)
+	}
+	lsn := binary.LittleEndian.Uint64(data[8:])
+	body := data[snapshotHeaderSize:]
+	if crc32.Checksum(body, castagnoli) != binary.LittleEndian.Uint32(data[16:]) {
+		return 0, fmt.Errorf("%w: checksum mismatch", ErrCorruptedSnapshot)
+	}
+
+	if err := tree.(encoding.BinaryUnmarshaler).UnmarshalBinary(body); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrCorruptedSnapshot, err)
+	}
+	return lsn, nil
+}
+
+// syncDir makes directory entries changes (file creation and renaming) durable.
+// Windows doesn't support syncing directories so it does nothing there
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	err = d.Sync()
+	if cerr := d.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
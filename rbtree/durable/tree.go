@@ -0,0 +1,345 @@
+package durable
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/aegoroff/godatastruct/rbtree"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrClosed is returned when the tree is used after closing
+var ErrClosed = errors.New("durable tree is closed")
+
+// ErrFailed is returned by modifications after the log sync failed or the log could not be restored
+// to a consistent state. The tree must be closed and reopened
+var ErrFailed = errors.New("durable tree failed")
+
+const (
+	logFileName      = "wal.log"
+	snapshotFileName = "snapshot"
+)
+
+// SyncPolicy defines when the log file is flushed to stable storage using fsync
+type SyncPolicy int
+
+const (
+	// SyncAlways syncs the log after every operation. No acknowledged operation is lost
+	// even if the machine crashes
+	SyncAlways SyncPolicy = iota
+
+	// SyncInterval syncs the log by the operation that comes at least Options.Interval after
+	// the previous sync. Operations written since the last sync may be lost if the machine crashes
+	SyncInterval
+
+	// SyncNever leaves flushing to the operating system. Operations survive process crash
+	// but may be lost if the machine crashes
+	SyncNever
+)
+
+// Options defines durable tree settings
+type Options struct {
+	// Codec serializes keys into the log and snapshot. It's required
+	Codec rbtree.KeyCodec
+
+	// Sync defines log sync policy
+	Sync SyncPolicy
+
+	// Interval is the minimal time between syncs for SyncInterval policy
+	Interval time.Duration
+
+	// CompactThreshold is the number of log records after which the log is compacted
+	// into a snapshot automatically. Zero disables automatic compaction
+	CompactThreshold int
+}
+
+// logFile is the part of *os.File used by the write-ahead log
+type logFile interface {
+	io.ReadWriteSeeker
+	io.Closer
+	Sync() error
+	Truncate(size int64) error
+}
+
+// Tree is Red-black tree which modifications are persisted into write-ahead log.
+// A modification that fails to be logged leaves both the tree and the log unchanged.
+// It isn't safe for concurrent use
+type Tree struct {
+	tree     rbtree.RbTree
+	opts     Options
+	dir      string
+	log      logFile
+	size     int64
+	lsn      uint64
+	records  int
+	lastSync time.Time
+	buf      []byte
+	err      error
+}
+
+// Open opens durable tree stored in the directory specified creating it if necessary.
+// The latest snapshot is loaded and the log written after it is replayed.
+// Incomplete record at the log end left by a crash is discarded while
+// corrupted record in the middle of the log makes Open fail with ErrCorruptedLog
+func Open(dir string, opts Options) (*Tree, error) {
+	if opts.Codec == nil {
+		return nil, rbtree.ErrNoKeyCodec
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	t := &Tree{
+		tree:     rbtree.NewWithCodec(opts.Codec),
+		opts:     opts,
+		dir:      dir,
+		lastSync: time.Now(),
+	}
+
+	lsn, err := readSnapshot(filepath.Join(dir, snapshotFileName), t.tree)
+	if err != nil {
+		return nil, err
+	}
+	t.lsn = lsn
+
+	f, err := os.OpenFile(filepath.Join(dir, logFileName), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	t.log = f
+	if err := t.replay(lsn); err != nil {
+		_ = t.log.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// Tree gets underlying tree to search and iterate keys.
+// IMPORTANT: the tree must not be modified directly because such modifications aren't logged
+func (t *Tree) Tree() rbtree.RbTree {
+	return t.tree
+}
+
+// Len returns the number of keys in the tree.
+func (t *Tree) Len() int64 {
+	return t.tree.Len()
+}
+
+// Search searches value specified within the tree
+func (t *Tree) Search(value rbtree.Comparable) (rbtree.Comparable, bool) {
+	return t.tree.Search(value)
+}
+
+// Insert logs and inserts the key specified
+func (t *Tree) Insert(c rbtree.Comparable) error {
+	if c == nil {
+		return nil
+	}
+	if err := t.append(opInsert, c); err != nil {
+		return err
+	}
+	t.tree.Insert(c)
+	return t.maybeCompact()
+}
+
+// ReplaceOrInsert logs and inserts the key specified replacing equal key if any.
+// The replaced key is returned
+func (t *Tree) ReplaceOrInsert(c rbtree.Comparable) (rbtree.Comparable, error) {
+	if c == nil {
+		return nil, nil
+	}
+	if err := t.append(opReplaceOrInsert, c); err != nil {
+		return nil, err
+	}
+	old := t.tree.ReplaceOrInsert(c)
+	return old, t.maybeCompact()
+}
+
+// Delete logs and deletes the first found key equal to the key specified.
+// Nothing is logged if there is no such key
+func (t *Tree) Delete(c rbtree.Comparable) (bool, error) {
+	if _, ok := t.tree.Search(c); !ok {
+		return false, nil
+	}
+	if err := t.append(opDelete, c); err != nil {
+		return false, err
+	}
+	t.tree.Delete(c)
+	return true, t.maybeCompact()
+}
+
+// Sync flushes the log to stable storage regardless of sync policy.
+// If it fails durability of records written since the previous sync is unknown
+// so the tree is switched to failed state and must be reopened
+func (t *Tree) Sync() error {
+	if err := t.writable(); err != nil {
+		return err
+	}
+	t.lastSync = time.Now()
+	if err := t.log.Sync(); err != nil {
+		return t.fail(err)
+	}
+	return nil
+}
+
+// Compact writes the whole tree into a new snapshot and truncates the log.
+// Log records already included into the snapshot are skipped by replay so
+// a crash between snapshot writing and log truncation loses nothing
+func (t *Tree) Compact() error {
+	if err := t.writable(); err != nil {
+		return err
+	}
+	if err := writeSnapshot(filepath.Join(t.dir, snapshotFileName), t.tree, t.lsn); err != nil {
+		return err
+	}
+	if err := t.truncate(0); err != nil {
+		return t.fail(err)
+	}
+	t.records = 0
+	return t.Sync()
+}
+
+// Close syncs and closes the log. The tree must not be modified after closing
+func (t *Tree) Close() error {
+	if t.log == nil {
+		return ErrClosed
+	}
+	err := t.log.Sync()
+	if cerr := t.log.Close(); err == nil {
+		err = cerr
+	}
+	t.log = nil
+	return err
+}
+
+// append writes the record of the operation specified into the log and syncs it according to policy.
+// On failure the log is truncated back to its size before the call so neither a partial record
+// breaks replay of the following ones nor an operation reported as failed reappears after reopening.
+// Failed write leaves the tree usable unless the log can't be truncated while failed sync always
+// switches the tree to failed state (see Sync)
+func (t *Tree) append(op opKind, c rbtree.Comparable) error {
+	if err := t.writable(); err != nil {
+		return err
+	}
+	key, err := t.opts.Codec.EncodeKey(nil, c)
+	if err != nil {
+		return err
+	}
+
+	t.buf = appendRecord(t.buf[:0], record{op: op, lsn: t.lsn + 1, key: key})
+	if _, err := t.log.Write(t.buf); err != nil {
+		return t.rollback(err)
+	}
+
+	switch t.opts.Sync {
+	case SyncAlways:
+		err = t.Sync()
+	case SyncInterval:
+		if time.Since(t.lastSync) >= t.opts.Interval {
+			err = t.Sync()
+		}
+	}
+	if err != nil {
+		_ = t.rollback(err)
+		return t.err
+	}
+
+	t.size += int64(len(t.buf))
+	t.lsn++
+	t.records++
+	return nil
+}
+
+// rollback truncates the log to the end of the last record written successfully
+func (t *Tree) rollback(cause error) error {
+	if err := t.truncate(t.size); err != nil {
+		return t.fail(errors.Join(cause, err))
+	}
+	return cause
+}
+
+// truncate cuts the log to the size specified and moves write position to its end
+func (t *Tree) truncate(size int64) error {
+	if err := t.log.Truncate(size); err != nil {
+		return err
+	}
+	if _, err := t.log.Seek(size, io.SeekStart); err != nil {
+		return err
+	}
+	t.size = size
+	return nil
+}
+
+// fail switches the tree into failed state so that all further modifications are refused.
+// The first failure cause is kept
+func (t *Tree) fail(cause error) error {
+	if t.err == nil {
+		t.err = fmt.Errorf("%w: %w", ErrFailed, cause)
+	}
+	return t.err
+}
+
+func (t *Tree) writable() error {
+	if t.log == nil {
+		return ErrClosed
+	}
+	return t.err
+}
+
+func (t *Tree) maybeCompact() error {
+	if t.opts.CompactThreshold > 0 && t.records >= t.opts.CompactThreshold {
+		return t.Compact()
+	}
+	return nil
+}
+
+// replay applies log records which LSN is greater than snapshot LSN specified
+// and truncates torn record at the log end. Corrupted record followed by other records isn't
+// truncated because acknowledged records after it would be lost, ErrCorruptedLog is returned instead
+func (t *Tree) replay(snapshotLSN uint64) error {
+	var offset int64
+	r := bufio.NewReader(t.log)
+	for {
+		rec, n, err := readRecord(r)
+		if err == io.EOF {
+			break
+		}
+		if errors.Is(err, errTornRecord) {
+			if err := t.log.Truncate(offset); err != nil {
+				return err
+			}
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("log offset %d: %w", offset, err)
+		}
+		offset += n
+		t.records++
+
+		if rec.lsn <= snapshotLSN {
+			continue
+		}
+		c, err := t.opts.Codec.DecodeKey(rec.key)
+		if err != nil {
+			return fmt.Errorf("log record %d: %w", rec.lsn, err)
+		}
+		switch rec.op {
+		case opInsert:
+			t.tree.Insert(c)
+		case opDelete:
+			t.tree.Delete(c)
+		case opReplaceOrInsert:
+			t.tree.ReplaceOrInsert(c)
+		default:
+			return fmt.Errorf("log record %d: unknown operation %d", rec.lsn, rec.op)
+		}
+		t.lsn = rec.lsn
+	}
+
+	t.size = offset
+	_, err := t.log.Seek(offset, io.SeekStart)
+	return err
+}
@@ -0,0 +1,28 @@
+package durable
+
+import (
+	"fmt"
+	"github.com/aegoroff/godatastruct/rbtree"
+	"os"
+)
+
+func ExampleOpen() {
+	dir, _ := os.MkdirTemp("", "durable")
+	defer os.RemoveAll(dir)
+
+	tree, _ := Open(dir, Options{Codec: rbtree.IntCodec, Sync: SyncAlways})
+	_ = tree.Insert(rbtree.Int(6))
+	_ = tree.Insert(rbtree.Int(18))
+	_ = tree.Insert(rbtree.Int(3))
+	_, _ = tree.Delete(rbtree.Int(6))
+	_ = tree.Close()
+
+	reopened, _ := Open(dir, Options{Codec: rbtree.IntCodec})
+	defer reopened.Close()
+	rbtree.NewAscend(reopened.Tree()).Foreach(func(c rbtree.Comparable) {
+		fmt.Println(c)
+	})
+	// Output:
+	// 3
+	// 18
+}
@@ -0,0 +1,429 @@
+package durable
+
+import (
+	"errors"
+	"github.com/aegoroff/godatastruct/rbtree"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+func Test_Reopen_OperationsReplayed(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	dir := t.TempDir()
+	tree, _ := Open(dir, Options{Codec: rbtree.IntCodec})
+	for _, n := range []int{6, 18, 3, 15, 3} {
+		_ = tree.Insert(rbtree.Int(n))
+	}
+	_, _ = tree.Delete(rbtree.Int(18))
+	_, _ = tree.ReplaceOrInsert(rbtree.Int(7))
+	_, _ = tree.ReplaceOrInsert(rbtree.Int(15))
+	_ = tree.Close()
+
+	// Act
+	reopened, err := Open(dir, Options{Codec: rbtree.IntCodec})
+
+	// Assert
+	ass.NoError(err)
+	defer reopened.Close()
+	ass.Equal([]int{3, 3, 6, 7, 15}, ascend(reopened))
+}
+
+func Test_Delete_NotExistingKey_NothingLogged(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	dir := t.TempDir()
+	tree, _ := Open(dir, Options{Codec: rbtree.IntCodec, Sync: SyncNever})
+	defer tree.Close()
+	_ = tree.Insert(rbtree.Int(1))
+	before := logSize(t, dir)
+
+	// Act
+	deleted, err := tree.Delete(rbtree.Int(2))
+
+	// Assert
+	ass.NoError(err)
+	ass.False(deleted)
+	ass.Equal(before, logSize(t, dir))
+}
+
+func Test_Compact_LogTruncatedKeysKept(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	dir := t.TempDir()
+	tree, _ := Open(dir, Options{Codec: rbtree.StringCodec, Sync: SyncInterval, Interval: time.Hour})
+	for _, s := range []string{"b", "a", "c"} {
+		_ = tree.Insert(rbtree.NewString(s))
+	}
+
+	// Act
+	err := tree.Compact()
+	_ = tree.Insert(rbtree.NewString("d"))
+	_ = tree.Close()
+	reopened, openErr := Open(dir, Options{Codec: rbtree.StringCodec})
+
+	// Assert
+	ass.NoError(err)
+	ass.NoError(openErr)
+	defer reopened.Close()
+	ass.Equal(int64(4), reopened.Len())
+	_, found := reopened.Search(rbtree.NewString("a"))
+	ass.True(found)
+}
+
+func Test_CompactThreshold_CompactedAutomatically(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	dir := t.TempDir()
+	tree, _ := Open(dir, Options{Codec: rbtree.IntCodec, CompactThreshold: 10})
+
+	// Act
+	for i := 0; i < 25; i++ {
+		_ = tree.Insert(rbtree.Int(i))
+	}
+	_ = tree.Close()
+
+	// Assert
+	ass.FileExists(filepath.Join(dir, snapshotFileName))
+	reopened, _ := Open(dir, Options{Codec: rbtree.IntCodec})
+	defer reopened.Close()
+	ass.Equal(int64(25), reopened.Len())
+	ass.Equal(5, reopened.records)
+}
+
+func Test_CrashBetweenSnapshotAndLogTruncation_NothingAppliedTwice(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	dir := t.TempDir()
+	tree, _ := Open(dir, Options{Codec: rbtree.IntCodec})
+	_ = tree.Insert(rbtree.Int(1))
+	_ = tree.Insert(rbtree.Int(2))
+	_ = writeSnapshot(filepath.Join(dir, snapshotFileName), tree.Tree(), tree.lsn)
+	_ = tree.Insert(rbtree.Int(3))
+	_ = tree.Close()
+
+	// Act
+	reopened, err := Open(dir, Options{Codec: rbtree.IntCodec})
+
+	// Assert
+	ass.NoError(err)
+	defer reopened.Close()
+	ass.Equal([]int{1, 2, 3}, ascend(reopened))
+}
+
+func Test_TornLogTail_Discarded(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	dir := t.TempDir()
+	tree, _ := Open(dir, Options{Codec: rbtree.IntCodec})
+	_ = tree.Insert(rbtree.Int(1))
+	_ = tree.Insert(rbtree.Int(2))
+	_ = tree.Close()
+	path := filepath.Join(dir, logFileName)
+	data, _ := os.ReadFile(path)
+	_ = os.WriteFile(path, data[:len(data)-1], 0o644)
+
+	// Act
+	reopened, err := Open(dir, Options{Codec: rbtree.IntCodec})
+	_ = reopened.Insert(rbtree.Int(3))
+	_ = reopened.Close()
+	again, againErr := Open(dir, Options{Codec: rbtree.IntCodec})
+
+	// Assert
+	ass.NoError(err)
+	ass.NoError(againErr)
+	defer again.Close()
+	ass.Equal([]int{1, 3}, ascend(again))
+}
+
+func Test_CorruptedSnapshot_Error(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	dir := t.TempDir()
+	tree, _ := Open(dir, Options{Codec: rbtree.IntCodec})
+	_ = tree.Insert(rbtree.Int(1))
+	_ = tree.Compact()
+	_ = tree.Close()
+	path := filepath.Join(dir, snapshotFileName)
+	data, _ := os.ReadFile(path)
+	data[len(data)-1]++
+	_ = os.WriteFile(path, data, 0o644)
+
+	// Act
+	_, err := Open(dir, Options{Codec: rbtree.IntCodec})
+
+	// Assert
+	ass.ErrorIs(err, ErrCorruptedSnapshot)
+}
+
+func Test_InvalidUsage_Error(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	dir := t.TempDir()
+	tree, _ := Open(dir, Options{Codec: rbtree.IntCodec})
+	_ = tree.Close()
+
+	// Act
+	_, noCodecErr := Open(dir, Options{})
+	insertErr := tree.Insert(rbtree.Int(1))
+	keyErr := func() error {
+		other, _ := Open(t.TempDir(), Options{Codec: rbtree.IntCodec})
+		defer other.Close()
+		return other.Insert(rbtree.NewString("a"))
+	}()
+
+	// Assert
+	ass.ErrorIs(noCodecErr, rbtree.ErrNoKeyCodec)
+	ass.ErrorIs(insertErr, ErrClosed)
+	ass.ErrorIs(tree.Close(), ErrClosed)
+	ass.ErrorIs(keyErr, rbtree.ErrInvalidKey)
+}
+
+func Test_FailedWrite_LogRolledBack(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	dir := t.TempDir()
+	tree, _ := Open(dir, Options{Codec: rbtree.IntCodec})
+	_ = tree.Insert(rbtree.Int(1))
+	before := logSize(t, dir)
+	fault := faultyLog{logFile: tree.log, failWrite: true}
+	tree.log = &fault
+
+	// Act
+	err := tree.Insert(rbtree.Int(2))
+	after := logSize(t, dir)
+	fault.failWrite = false
+	nextErr := tree.Insert(rbtree.Int(3))
+	_ = tree.Close()
+	reopened, _ := Open(dir, Options{Codec: rbtree.IntCodec})
+	defer reopened.Close()
+
+	// Assert
+	ass.ErrorIs(err, errFault)
+	ass.NotErrorIs(err, ErrFailed)
+	ass.Equal(before, after)
+	ass.NoError(nextErr)
+	ass.Equal([]int{1, 3}, ascend(reopened))
+}
+
+func Test_FailedSync_TreeFailed(t *testing.T) {
+	var tests = []struct {
+		name     string
+		opts     Options
+		act      func(tree *Tree) error
+		expected []int
+	}{
+		{
+			"always",
+			Options{Codec: rbtree.IntCodec, Sync: SyncAlways},
+			func(tree *Tree) error { return tree.Insert(rbtree.Int(2)) },
+			[]int{1},
+		},
+		{
+			"interval",
+			Options{Codec: rbtree.IntCodec, Sync: SyncInterval, Interval: time.Hour},
+			func(tree *Tree) error {
+				_ = tree.Insert(rbtree.Int(2))
+				return tree.Sync()
+			},
+			[]int{1, 2},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			dir := t.TempDir()
+			tree, _ := Open(dir, test.opts)
+			_ = tree.Insert(rbtree.Int(1))
+			_ = tree.Sync()
+			fault := faultyLog{logFile: tree.log, failSync: true}
+			tree.log = &fault
+
+			// Act
+			err := test.act(tree)
+			fault.failSync = false
+			insertErr := tree.Insert(rbtree.Int(3))
+			_, deleteErr := tree.Delete(rbtree.Int(1))
+			syncErr := tree.Sync()
+			compactErr := tree.Compact()
+
+			// Assert
+			ass.ErrorIs(err, ErrFailed)
+			ass.ErrorIs(err, errFault)
+			ass.ErrorIs(insertErr, ErrFailed)
+			ass.ErrorIs(deleteErr, ErrFailed)
+			ass.ErrorIs(syncErr, ErrFailed)
+			ass.ErrorIs(compactErr, ErrFailed)
+			ass.Equal(test.expected, ascend(tree))
+			ass.NoError(tree.Close())
+			reopened, _ := Open(dir, test.opts)
+			defer reopened.Close()
+			ass.Equal(test.expected, ascend(reopened))
+		})
+	}
+}
+
+func Test_CorruptedLogRecord(t *testing.T) {
+	var tests = []struct {
+		name     string
+		record   int
+		expected []int
+		err      error
+	}{
+		{"middle", 1, nil, ErrCorruptedLog},
+		{"last", 2, []int{1, 2}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			dir := t.TempDir()
+			tree, _ := Open(dir, Options{Codec: rbtree.IntCodec})
+			var ends []int64
+			for _, n := range []int{1, 2, 3} {
+				_ = tree.Insert(rbtree.Int(n))
+				ends = append(ends, logSize(t, dir))
+			}
+			_ = tree.Close()
+			path := filepath.Join(dir, logFileName)
+			data, _ := os.ReadFile(path)
+			data[ends[test.record]-1]++
+			_ = os.WriteFile(path, data, 0o644)
+
+			// Act
+			reopened, err := Open(dir, Options{Codec: rbtree.IntCodec})
+
+			// Assert
+			if test.err != nil {
+				ass.ErrorIs(err, test.err)
+				ass.Equal(int64(len(data)), logSize(t, dir))
+				return
+			}
+			ass.NoError(err)
+			defer reopened.Close()
+			ass.Equal(test.expected, ascend(reopened))
+			ass.Equal(ends[test.record-1], logSize(t, dir))
+		})
+	}
+}
+
+func Test_FailedRollback_TreeFailed(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	dir := t.TempDir()
+	tree, _ := Open(dir, Options{Codec: rbtree.IntCodec})
+	_ = tree.Insert(rbtree.Int(1))
+	fault := faultyLog{logFile: tree.log, failSync: true, failTruncate: true}
+	tree.log = &fault
+
+	// Act
+	err := tree.Insert(rbtree.Int(2))
+	fault.failSync, fault.failTruncate = false, false
+	nextErr := tree.Insert(rbtree.Int(3))
+	compactErr := tree.Compact()
+
+	// Assert
+	ass.ErrorIs(err, ErrFailed)
+	ass.ErrorIs(err, errFault)
+	ass.ErrorIs(nextErr, ErrFailed)
+	ass.ErrorIs(compactErr, ErrFailed)
+	ass.Equal([]int{1}, ascend(tree))
+	ass.NoError(tree.Close())
+}
+
+func Test_RandomOperations_EqualToSortedSliceAfterReopen(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	r := rand.New(rand.NewSource(1000))
+	dir := t.TempDir()
+	opts := Options{Codec: rbtree.IntCodec, Sync: SyncNever, CompactThreshold: 97}
+	tree, _ := Open(dir, opts)
+	var expected []int
+
+	// Act
+	for i := 0; i < 1000; i++ {
+		k := r.Intn(100)
+		ix := sort.SearchInts(expected, k)
+		exists := ix < len(expected) && expected[ix] == k
+		switch r.Intn(3) {
+		case 0:
+			_ = tree.Insert(rbtree.Int(k))
+			expected = append(expected[:ix], append([]int{k}, expected[ix:]...)...)
+		case 1:
+			_, _ = tree.Delete(rbtree.Int(k))
+			if exists {
+				expected = append(expected[:ix], expected[ix+1:]...)
+			}
+		default:
+			_, _ = tree.ReplaceOrInsert(rbtree.Int(k))
+			if !exists {
+				expected = append(expected[:ix], append([]int{k}, expected[ix:]...)...)
+			}
+		}
+		if i%250 == 0 {
+			_ = tree.Close()
+			tree, _ = Open(dir, opts)
+		}
+	}
+	_ = tree.Close()
+	reopened, _ := Open(dir, opts)
+	defer reopened.Close()
+
+	// Assert
+	ass.Equal(expected, ascend(reopened))
+}
+
+func ascend(t *Tree) []int {
+	var result []int
+	rbtree.NewAscend(t.Tree()).Foreach(func(c rbtree.Comparable) {
+		result = append(result, rbtree.GetInt(c))
+	})
+	return result
+}
+
+func logSize(t *testing.T, dir string) int64 {
+	fi, err := os.Stat(filepath.Join(dir, logFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fi.Size()
+}
+
+var errFault = errors.New("injected fault")
+
+// faultyLog wraps log file and fails its operations on demand.
+// Failing write writes half of the data before returning error
+type faultyLog struct {
+	logFile
+	failWrite    bool
+	failSync     bool
+	failTruncate bool
+}
+
+func (f *faultyLog) Write(p []byte) (int, error) {
+	if f.failWrite {
+		n, _ := f.logFile.Write(p[:len(p)/2])
+		return n, errFault
+	}
+	return f.logFile.Write(p)
+}
+
+func (f *faultyLog) Sync() error {
+	if f.failSync {
+		return errFault
+	}
+	return f.logFile.Sync()
+}
+
+func (f *faultyLog) Truncate(size int64) error {
+	if f.failTruncate {
+		return errFault
+	}
+	return f.logFile.Truncate(size)
+}
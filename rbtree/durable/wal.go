@@ -0,0 +1,107 @@
+package durable
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+)
+
+// This file contains write-ahead log records encoding
+
+type opKind byte
+
+const (
+	opInsert opKind = iota + 1
+	opDelete
+	opReplaceOrInsert
+)
+
+// recordHeaderSize is the size of payload length and payload CRC-32C preceding every record payload
+const recordHeaderSize = 8
+
+// maxRecordSize limits record payload so corrupted length cannot cause huge allocation
+const maxRecordSize = 1 << 30
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// ErrCorruptedLog is returned by Open when a log record is corrupted but isn't the last data
+// in the log so it cannot be the result of a crash in the middle of appending
+var ErrCorruptedLog = errors.New("corrupted log")
+
+// errTornRecord is returned when the log ends with incomplete or corrupted record
+// that is the result of a crash in the middle of appending
+var errTornRecord = errors.New("torn log record")
+
+type record struct {
+	op  opKind
+	lsn uint64
+	key []byte
+}
+
+// appendRecord appends encoded record to dst
+func appendRecord(dst []byte, r record) []byte {
+	start := len(dst)
+	dst = append(dst, make([]byte, recordHeaderSize)...)
+	dst = append(dst, byte(r.op))
+	dst = binary.AppendUvarint(dst, r.lsn)
+	dst = append(dst, r.key...)
+
+	payload := dst[start+recordHeaderSize:]
+	binary.LittleEndian.PutUint32(dst[start:], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(dst[start+4:], crc32.Checksum(payload, castagnoli))
+	return dst
+}
+
+// readRecord reads the next record from r. It returns io.EOF if there are no more records
+// and errTornRecord if the record is incomplete or it's corrupted and no other data follows it
+// (the tail left by a crash). ErrCorruptedLog is returned if corrupted record is followed by other data
+func readRecord(r *bufio.Reader) (record, int64, error) {
+	header := make([]byte, recordHeaderSize)
+	n, err := io.ReadFull(r, header)
+	if err == io.EOF {
+		return record{}, 0, io.EOF
+	}
+	if err != nil {
+		return record{}, int64(n), errTornRecord
+	}
+
+	size := binary.LittleEndian.Uint32(header)
+	if size < 2 || size > maxRecordSize {
+		return record{}, int64(n), corrupted(r)
+	}
+	payload := make([]byte, size)
+	m, err := io.ReadFull(r, payload)
+	if err != nil {
+		return record{}, int64(n + m), errTornRecord
+	}
+	if crc32.Checksum(payload, castagnoli) != binary.LittleEndian.Uint32(header[4:]) {
+		return record{}, int64(n + m), corrupted(r)
+	}
+
+	lsn, l := binary.Uvarint(payload[1:])
+	if l <= 0 {
+		return record{}, int64(n + m), corrupted(r)
+	}
+	rec := record{
+		op:  opKind(payload[0]),
+		lsn: lsn,
+		key: payload[1+l:],
+	}
+	return rec, int64(n + m), nil
+}
+
+// corrupted gets errTornRecord if corrupted record is the last data in the log i.e. nothing
+// or only zeroes (file extended by a crash but not written) remain, and ErrCorruptedLog otherwise
+func corrupted(r *bufio.Reader) error {
+	rest, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if len(bytes.Trim(rest, "\x00")) == 0 {
+		return errTornRecord
+	}
+	return ErrCorruptedLog
+}
@@ -0,0 +1,102 @@
+package rbtree
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// This file contains RB tree binary serialization implementation
+
+// ErrNoKeyCodec is returned when tree keys cannot be encoded or decoded
+// because KeyCodec isn't set and cannot be inferred from keys type
+var ErrNoKeyCodec = errors.New("key codec not set")
+
+// ErrInvalidData is returned when binary representation of the tree is malformed
+var ErrInvalidData = errors.New("invalid tree binary data")
+
+const binaryVersion = 1
+
+var binaryMagic = []byte{'R', 'B', 'T'}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+// Keys are written in ascending order using tree's KeyCodec or
+// built-in codec inferred from keys type (Int, Int64 or *String)
+func (tree *rbTree) MarshalBinary() ([]byte, error) {
+	codec := tree.codec
+	if codec == nil && tree.root.isNotNil() {
+		codec = inferCodec(tree.Minimum().key)
+		if codec == nil {
+			return nil, ErrNoKeyCodec
+		}
+	}
+
+	data := append([]byte{}, binaryMagic...)
+	data = append(data, binaryVersion, codecKind(codec))
+	data = binary.AppendUvarint(data, uint64(tree.Len()))
+
+	var key []byte
+	var err error
+	for n := tree.Minimum(); n != nil; n = n.Successor() {
+		key, err = codec.EncodeKey(key[:0], n.key)
+		if err != nil {
+			return nil, err
+		}
+		data = binary.AppendUvarint(data, uint64(len(key)))
+		data = append(data, key...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+// It replaces tree content with keys decoded. Because keys are stored in ascending order
+// the tree is constructed in linear time without rebalancing
+func (tree *rbTree) UnmarshalBinary(data []byte) error {
+	header := len(binaryMagic) + 2
+	if len(data) < header || string(data[:len(binaryMagic)]) != string(binaryMagic) {
+		return fmt.Errorf("%w: bad header", ErrInvalidData)
+	}
+	if data[len(binaryMagic)] != binaryVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidData, data[len(binaryMagic)])
+	}
+
+	kind := data[len(binaryMagic)+1]
+	codec := tree.codec
+	if codec == nil {
+		codec = builtinCodec(kind)
+	}
+	data = data[header:]
+
+	count, n := binary.Uvarint(data)
+	if n <= 0 || count > uint64(len(data)) {
+		return fmt.Errorf("%w: bad keys count", ErrInvalidData)
+	}
+	data = data[n:]
+	if codec == nil && count > 0 {
+		return ErrNoKeyCodec
+	}
+
+	keys := make([]Comparable, 0, count)
+	for i := uint64(0); i < count; i++ {
+		l, n := binary.Uvarint(data)
+		if n <= 0 || l > uint64(len(data)-n) {
+			return fmt.Errorf("%w: key %d truncated", ErrInvalidData, i)
+		}
+		c, err := codec.DecodeKey(data[n : n+int(l)])
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 && c.Less(keys[len(keys)-1]) {
+			return fmt.Errorf("%w: keys aren't sorted", ErrInvalidData)
+		}
+		keys = append(keys, c)
+		data = data[n+int(l):]
+	}
+
+	if len(data) > 0 {
+		return fmt.Errorf("%w: unexpected trailing data", ErrInvalidData)
+	}
+
+	tree.build(keys)
+	return nil
+}
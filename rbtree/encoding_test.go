@@ -0,0 +1,259 @@
+package rbtree
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+type point struct{ x, y int32 }
+
+type pointCodec struct{}
+
+func (p point) Less(y Comparable) bool {
+	o := y.(point)
+	return p.x < o.x || (p.x == o.x && p.y < o.y)
+}
+
+func (p point) Equal(y Comparable) bool {
+	return p == y
+}
+
+func (pointCodec) EncodeKey(dst []byte, c Comparable) ([]byte, error) {
+	p := c.(point)
+	dst = binary.BigEndian.AppendUint32(dst, uint32(p.x))
+	return binary.BigEndian.AppendUint32(dst, uint32(p.y)), nil
+}
+
+func (pointCodec) DecodeKey(data []byte) (Comparable, error) {
+	if len(data) != 8 {
+		return nil, ErrInvalidKey
+	}
+	return point{x: int32(binary.BigEndian.Uint32(data)), y: int32(binary.BigEndian.Uint32(data[4:]))}, nil
+}
+
+func Test_MarshalUnmarshalBinary_ContentAndStructureValid(t *testing.T) {
+	var tests = []struct {
+		name string
+		size int
+	}{
+		{"empty", 0},
+		{"one", 1},
+		{"two", 2},
+		{"three", 3},
+		{"complete", 15},
+		{"not complete", 16},
+		{"large", 1000},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			r := rand.New(rand.NewSource(int64(test.size)))
+			src := New()
+			for i := 0; i < test.size; i++ {
+				src.Insert(Int(r.Intn(test.size)))
+			}
+			dst := New()
+
+			// Act
+			data, err := src.(encoding.BinaryMarshaler).MarshalBinary()
+			ass.NoError(err)
+			err = dst.(encoding.BinaryUnmarshaler).UnmarshalBinary(data)
+
+			// Assert
+			ass.NoError(err)
+			ass.Equal(src.Len(), dst.Len())
+			ass.Equal(ascendInts(src), ascendInts(dst))
+			ass.True(isValidRbTree(dst))
+		})
+	}
+}
+
+func Test_UnmarshalBinary_TreeModifiableAfterwards(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	data, _ := newIntTestTree().(encoding.BinaryMarshaler).MarshalBinary()
+	tree := New()
+	_ = tree.(encoding.BinaryUnmarshaler).UnmarshalBinary(data)
+
+	// Act
+	tree.Insert(Int(5))
+	tree.Delete(Int(13))
+	tree.Delete(Int(2))
+
+	// Assert
+	ass.Equal([]int{3, 4, 5, 6, 7, 9, 15, 17, 18, 20}, ascendInts(tree))
+	ass.True(isValidRbTree(tree))
+}
+
+func Test_MarshalUnmarshalBinaryBuiltinCodecs_RoundTrip(t *testing.T) {
+	var tests = []struct {
+		name  string
+		codec KeyCodec
+		keys  []Comparable
+	}{
+		{"int", nil, []Comparable{Int(-3), Int(0), Int(100)}},
+		{"int64", nil, []Comparable{Int64(-3), Int64(0), Int64(1 << 40)}},
+		{"string", nil, []Comparable{NewString(""), NewString("a"), NewString("b")}},
+		{"int with codec", IntCodec, []Comparable{Int(1), Int(2)}},
+		{"custom", pointCodec{}, []Comparable{point{1, 2}, point{1, 3}, point{2, 0}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			src := NewWithCodec(test.codec)
+			for _, k := range test.keys {
+				src.Insert(k)
+			}
+			dst := NewWithCodec(test.codec)
+
+			// Act
+			data, err := src.(encoding.BinaryMarshaler).MarshalBinary()
+			ass.NoError(err)
+			err = dst.(encoding.BinaryUnmarshaler).UnmarshalBinary(data)
+
+			// Assert
+			ass.NoError(err)
+			result := make([]Comparable, 0)
+			NewAscend(dst).Foreach(func(c Comparable) {
+				result = append(result, c)
+			})
+			ass.Equal(test.keys, result)
+		})
+	}
+}
+
+func Test_Gob_RoundTrip(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	src := newTestStringTree()
+	dst := New()
+	var buf bytes.Buffer
+
+	// Act
+	err := gob.NewEncoder(&buf).Encode(src)
+	ass.NoError(err)
+	err = gob.NewDecoder(&buf).Decode(dst)
+
+	// Assert
+	ass.NoError(err)
+	ass.Equal(src.Len(), dst.Len())
+	found, ok := dst.Search(NewString("microsoft"))
+	ass.True(ok)
+	ass.Equal("microsoft", found.(*String).String())
+}
+
+func Test_MarshalBinaryCustomKeyWithoutCodec_Error(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	tree.Insert(point{1, 1})
+
+	// Act
+	_, err := tree.(encoding.BinaryMarshaler).MarshalBinary()
+
+	// Assert
+	ass.ErrorIs(err, ErrNoKeyCodec)
+}
+
+func Test_MarshalBinaryWrongCodec_Error(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := NewWithCodec(IntCodec)
+	tree.Insert(Int64(1))
+
+	// Act
+	_, err := tree.(encoding.BinaryMarshaler).MarshalBinary()
+
+	// Assert
+	ass.ErrorIs(err, ErrInvalidKey)
+}
+
+func Test_UnmarshalBinaryInvalidData_Error(t *testing.T) {
+	valid, _ := newIntTestTree().(encoding.BinaryMarshaler).MarshalBinary()
+	unsorted := []byte{'R', 'B', 'T', 1, intCodecKind, 2, 1, 4, 1, 2}
+	custom := NewWithCodec(pointCodec{})
+	custom.Insert(point{1, 1})
+	customData, _ := custom.(encoding.BinaryMarshaler).MarshalBinary()
+
+	var tests = []struct {
+		name     string
+		data     []byte
+		expected error
+	}{
+		{"empty", []byte{}, ErrInvalidData},
+		{"bad magic", []byte{'R', 'B', 'X', 1, 0, 0}, ErrInvalidData},
+		{"bad version", []byte{'R', 'B', 'T', 2, 0, 0}, ErrInvalidData},
+		{"no count", []byte{'R', 'B', 'T', 1, 0}, ErrInvalidData},
+		{"truncated", valid[:len(valid)-1], ErrInvalidData},
+		{"trailing", append(append([]byte{}, valid...), 0), ErrInvalidData},
+		{"unsorted", unsorted, ErrInvalidData},
+		{"huge count", []byte{'R', 'B', 'T', 1, intCodecKind, 0xff, 0xff, 0xff, 0xff, 0x0f}, ErrInvalidData},
+		{"custom without codec", customData, ErrNoKeyCodec},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			tree := newIntTestTree()
+
+			// Act
+			err := tree.(encoding.BinaryUnmarshaler).UnmarshalBinary(test.data)
+
+			// Assert
+			ass.ErrorIs(err, test.expected)
+			ass.Equal(int64(11), tree.Len())
+		})
+	}
+}
+
+func ascendInts(t RbTree) []int {
+	result := make([]int, 0)
+	NewAscend(t).Foreach(func(c Comparable) {
+		result = append(result, GetInt(c))
+	})
+	return result
+}
+
+// isValidRbTree validates red-black tree properties, subtree sizes and parents
+func isValidRbTree(t RbTree) bool {
+	root := t.Root()
+	if root.isNil() {
+		return true
+	}
+	if root.color != black {
+		return false
+	}
+	_, ok := validateSubtree(root)
+	return ok
+}
+
+func validateSubtree(n *Node) (int, bool) {
+	if n.isNil() {
+		return 1, true
+	}
+	if n.color == red && (n.left.color == red || n.right.color == red) {
+		return 0, false
+	}
+	if (n.left.isNotNil() && n.left.parent != n) || (n.right.isNotNil() && n.right.parent != n) {
+		return 0, false
+	}
+	if n.size != n.left.size+n.right.size+1 {
+		return 0, false
+	}
+	lh, lok := validateSubtree(n.left)
+	rh, rok := validateSubtree(n.right)
+	if !lok || !rok || lh != rh {
+		return 0, false
+	}
+	if n.color == black {
+		lh++
+	}
+	return lh, true
+}
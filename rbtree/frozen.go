@@ -0,0 +1,239 @@
+package rbtree
+
+import "math/bits"
+
+// This file contains frozen (read-only static) tree implementation
+
+// FrozenTree is an immutable snapshot of tree keys stored in Eytzinger (breadth-first) layout
+// over a sorted array i.e. the key at position k has children at positions 2k and 2k+1.
+// Searches walk the array top down by index arithmetic instead of following node links
+// but keys are still Comparable interfaces: every comparison is a dynamic call and keys
+// that aren't pointers are boxed on the heap. Besides keys it keeps two parallel arrays
+// that map Eytzinger positions to ranks and back (for Rank, OrderStatisticSelect and ranges),
+// so it takes 32 bytes per key plus keys themselves.
+// Create it using Freeze
+type FrozenTree struct {
+	// keys in Eytzinger order starting from index 1. Index 0 is unused
+	keys []Comparable
+
+	// ranks maps Eytzinger position to zero based rank
+	ranks []int64
+
+	// positions maps zero based rank to Eytzinger position
+	positions []int64
+}
+
+type frozenAscend struct {
+	enumerable
+	tree *FrozenTree
+	curr int64
+	next int64
+	to   int64
+}
+
+type frozenDescend struct{ frozenAscend }
+
+// Freeze creates FrozenTree that contains all keys of the tree specified in O(n).
+// Later tree modifications don't affect the result
+func Freeze(t RbTree) *FrozenTree {
+	n := t.Len()
+	sorted := make([]Comparable, 0, n)
+	NewAscend(t).Foreach(func(c Comparable) {
+		sorted = append(sorted, c)
+	})
+
+	f := &FrozenTree{
+		keys:      make([]Comparable, n+1),
+		ranks:     make([]int64, n+1),
+		positions: make([]int64, n),
+	}
+
+	var rank int64
+	var fill func(k int64)
+	fill = func(k int64) {
+		if k > n {
+			return
+		}
+		fill(2 * k)
+		f.keys[k] = sorted[rank]
+		f.ranks[k] = rank
+		f.positions[rank] = k
+		rank++
+		fill(2*k + 1)
+	}
+	fill(1)
+
+	return f
+}
+
+// Len returns the number of keys in the tree.
+func (f *FrozenTree) Len() int64 {
+	return int64(len(f.positions))
+}
+
+// Search searches value specified within frozen tree
+func (f *FrozenTree) Search(value Comparable) (Comparable, bool) {
+	if value == nil {
+		return nil, false
+	}
+	k := f.lowerBound(value)
+	if k == 0 || !f.keys[k].Equal(value) {
+		return nil, false
+	}
+	return f.keys[k], true
+}
+
+// Floor searches the greatest key lesser than or equal to value specified.
+// Unlike RbTree.Floor false is returned if all keys are greater than value
+func (f *FrozenTree) Floor(value Comparable) (Comparable, bool) {
+	if value == nil {
+		return nil, false
+	}
+	r := f.rankOf(f.upperBound(value))
+	return f.OrderStatisticSelect(r)
+}
+
+// Ceiling searches the smallest key larger than or equal to value specified.
+// It returns false if all keys are less than value
+func (f *FrozenTree) Ceiling(value Comparable) (Comparable, bool) {
+	if value == nil {
+		return nil, false
+	}
+	k := f.lowerBound(value)
+	if k == 0 {
+		return nil, false
+	}
+	return f.keys[k], true
+}
+
+// Minimum gets tree's min key
+func (f *FrozenTree) Minimum() (Comparable, bool) {
+	return f.OrderStatisticSelect(1)
+}
+
+// Maximum gets tree's max key
+func (f *FrozenTree) Maximum() (Comparable, bool) {
+	return f.OrderStatisticSelect(f.Len())
+}
+
+// OrderStatisticSelect gets i key from the tree in O(1)
+// IMPORTANT: numeration starts from 1 not from 0
+func (f *FrozenTree) OrderStatisticSelect(i int64) (Comparable, bool) {
+	if i < 1 || i > f.Len() {
+		return nil, false
+	}
+	return f.keys[f.positions[i-1]], true
+}
+
+// Rank gets the rank (starting from 1) of the first key equal to value specified
+func (f *FrozenTree) Rank(value Comparable) (int64, bool) {
+	if value == nil {
+		return 0, false
+	}
+	k := f.lowerBound(value)
+	if k == 0 || !f.keys[k].Equal(value) {
+		return 0, false
+	}
+	return f.ranks[k] + 1, true
+}
+
+// NewAscend creates Enumerable that walks all keys in ascending order
+func (f *FrozenTree) NewAscend() Enumerable {
+	return f.newAscend(0, f.Len()-1)
+}
+
+// NewAscendRange creates Enumerable that walks keys in ascending order within the range [from, to].
+// Both ends not necessary present in the tree
+func (f *FrozenTree) NewAscendRange(from, to Comparable) Enumerable {
+	if from == nil || to == nil {
+		return f.newAscend(0, -1)
+	}
+	return f.newAscend(f.rankOf(f.lowerBound(from)), f.rankOf(f.upperBound(to))-1)
+}
+
+// NewDescend creates Enumerable that walks all keys in descending order
+func (f *FrozenTree) NewDescend() Enumerable {
+	return f.newDescend(f.Len()-1, 0)
+}
+
+// NewDescendRange creates Enumerable that walks keys in descending order within the range [to, from].
+// Both ends not necessary present in the tree
+func (f *FrozenTree) NewDescendRange(from, to Comparable) Enumerable {
+	if from == nil || to == nil {
+		return f.newDescend(-1, 0)
+	}
+	return f.newDescend(f.rankOf(f.upperBound(from))-1, f.rankOf(f.lowerBound(to)))
+}
+
+func (i *frozenAscend) Next() bool {
+	result := i.next <= i.to
+	if result {
+		i.curr = i.next
+		i.next++
+	}
+	return result
+}
+
+func (i *frozenDescend) Next() bool {
+	result := i.next >= i.to && i.next >= 0
+	if result {
+		i.curr = i.next
+		i.next--
+	}
+	return result
+}
+
+func (i *frozenAscend) Current() Comparable {
+	return i.tree.keys[i.tree.positions[i.curr]]
+}
+
+func (f *FrozenTree) newAscend(from, to int64) *frozenAscend {
+	e := &frozenAscend{tree: f, next: from, to: to}
+	e.it = e
+	return e
+}
+
+func (f *FrozenTree) newDescend(from, to int64) *frozenDescend {
+	e := &frozenDescend{frozenAscend{tree: f, next: from, to: to}}
+	e.it = e
+	return e
+}
+
+// rankOf gets zero based rank of the key at Eytzinger position k
+// or the number of keys if k is zero (i.e. position past the last key)
+func (f *FrozenTree) rankOf(k int64) int64 {
+	if k == 0 {
+		return f.Len()
+	}
+	return f.ranks[k]
+}
+
+// lowerBound gets Eytzinger position of the first key that isn't less than value
+// or zero if all keys are less than value
+func (f *FrozenTree) lowerBound(value Comparable) int64 {
+	n := f.Len()
+	k := int64(1)
+	for k <= n {
+		if f.keys[k].Less(value) {
+			k = 2*k + 1
+		} else {
+			k = 2 * k
+		}
+	}
+	return k >> (bits.TrailingZeros64(^uint64(k)) + 1)
+}
+
+// upperBound gets Eytzinger position of the first key that is greater than value
+// or zero if all keys are less than or equal to value
+func (f *FrozenTree) upperBound(value Comparable) int64 {
+	n := f.Len()
+	k := int64(1)
+	for k <= n {
+		if value.Less(f.keys[k]) {
+			k = 2 * k
+		} else {
+			k = 2*k + 1
+		}
+	}
+	return k >> (bits.TrailingZeros64(^uint64(k)) + 1)
+}
@@ -0,0 +1,197 @@
+package rbtree
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func Test_Freeze_SameKeysAsTree(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTestTree()
+
+	// Act
+	frozen := Freeze(tree)
+
+	// Assert
+	ass.Equal(tree.Len(), frozen.Len())
+	ass.Equal(collect(NewAscend(tree)), collect(frozen.NewAscend()))
+	ass.Equal(collect(NewDescend(tree)), collect(frozen.NewDescend()))
+}
+
+func Test_Freeze_TreeModifiedAfterFreeze_FrozenNotChanged(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTree([]int{1, 2, 3})
+	frozen := Freeze(tree)
+
+	// Act
+	tree.Insert(Int(4))
+	tree.Delete(Int(1))
+
+	// Assert
+	ass.Equal(int64(3), frozen.Len())
+	_, found1 := frozen.Search(Int(1))
+	_, found4 := frozen.Search(Int(4))
+	ass.True(found1)
+	ass.False(found4)
+}
+
+func Test_FrozenTree_EmptyTree_NothingFound(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	frozen := Freeze(New())
+
+	// Act
+	_, found := frozen.Search(Int(1))
+	_, floor := frozen.Floor(Int(1))
+	_, ceiling := frozen.Ceiling(Int(1))
+	_, min := frozen.Minimum()
+	_, max := frozen.Maximum()
+	_, sel := frozen.OrderStatisticSelect(1)
+	_, rank := frozen.Rank(Int(1))
+
+	// Assert
+	ass.Equal(int64(0), frozen.Len())
+	ass.False(found)
+	ass.False(floor)
+	ass.False(ceiling)
+	ass.False(min)
+	ass.False(max)
+	ass.False(sel)
+	ass.False(rank)
+	ass.Empty(collect(frozen.NewAscend()))
+	ass.Empty(collect(frozen.NewDescend()))
+	ass.Empty(collect(frozen.NewAscendRange(Int(1), Int(10))))
+	ass.Empty(collect(frozen.NewDescendRange(Int(10), Int(1))))
+}
+
+func Test_FrozenTree_FloorCeiling(t *testing.T) {
+	var tests = []struct {
+		x       int
+		floor   Comparable
+		ceiling Comparable
+	}{
+		{1, nil, Int(2)},
+		{2, Int(2), Int(2)},
+		{5, Int(4), Int(6)},
+		{14, Int(13), Int(15)},
+		{20, Int(20), Int(20)},
+		{21, Int(20), nil},
+	}
+	for _, test := range tests {
+		// Arrange
+		ass := assert.New(t)
+		frozen := Freeze(newIntTestTree())
+
+		// Act
+		floor, floorOk := frozen.Floor(Int(test.x))
+		ceiling, ceilingOk := frozen.Ceiling(Int(test.x))
+
+		// Assert
+		ass.Equal(test.floor != nil, floorOk, "x = %d", test.x)
+		ass.Equal(test.floor, floor, "x = %d", test.x)
+		ass.Equal(test.ceiling != nil, ceilingOk, "x = %d", test.x)
+		ass.Equal(test.ceiling, ceiling, "x = %d", test.x)
+	}
+}
+
+func Test_FrozenTree_NilValue_NothingFound(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	frozen := Freeze(newIntTestTree())
+
+	// Act
+	found, search := frozen.Search(nil)
+	_, floor := frozen.Floor(nil)
+	_, ceiling := frozen.Ceiling(nil)
+	_, rank := frozen.Rank(nil)
+
+	// Assert
+	ass.False(search)
+	ass.Nil(found)
+	ass.False(floor)
+	ass.False(ceiling)
+	ass.False(rank)
+	ass.Empty(collect(frozen.NewAscendRange(nil, Int(10))))
+	ass.Empty(collect(frozen.NewDescendRange(Int(10), nil)))
+}
+
+func Test_FrozenTree_Ranges(t *testing.T) {
+	var tests = []struct {
+		from       int
+		to         int
+		ascending  []int
+		descending []int
+	}{
+		{6, 15, []int{6, 7, 9, 13, 15}, nil},
+		{5, 14, []int{6, 7, 9, 13}, nil},
+		{15, 6, nil, []int{15, 13, 9, 7, 6}},
+		{14, 5, nil, []int{13, 9, 7, 6}},
+		{-10, 3, []int{2, 3}, nil},
+		{100, 19, nil, []int{20}},
+		{21, 100, nil, nil},
+	}
+	for _, test := range tests {
+		// Arrange
+		ass := assert.New(t)
+		frozen := Freeze(newIntTestTree())
+
+		// Act
+		ascending := collectInts(frozen.NewAscendRange(Int(test.from), Int(test.to)))
+		descending := collectInts(frozen.NewDescendRange(Int(test.from), Int(test.to)))
+
+		// Assert
+		ass.Equal(test.ascending, ascending, "[%d, %d]", test.from, test.to)
+		ass.Equal(test.descending, descending, "[%d, %d]", test.from, test.to)
+	}
+}
+
+func Test_FrozenTree_RandomKeysWithDuplicates_EqualToSortedSlice(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	r := rand.New(rand.NewSource(1000))
+	tree := New()
+	keys := make([]int, 1000)
+	for i := range keys {
+		keys[i] = r.Intn(300) * 2
+		tree.Insert(Int(keys[i]))
+	}
+	sort.Ints(keys)
+
+	// Act
+	frozen := Freeze(tree)
+
+	// Assert
+	for i, k := range keys {
+		c, ok := frozen.OrderStatisticSelect(int64(i + 1))
+		ass.True(ok)
+		ass.Equal(Int(k), c)
+	}
+	for x := -1; x < 602; x++ {
+		ix := sort.SearchInts(keys, x)
+		found := ix < len(keys) && keys[ix] == x
+		_, ok := frozen.Search(Int(x))
+		rank, rankOk := frozen.Rank(Int(x))
+		ceiling, ceilingOk := frozen.Ceiling(Int(x))
+		ass.Equal(found, ok, "x = %d", x)
+		ass.Equal(found, rankOk, "x = %d", x)
+		if found {
+			ass.Equal(int64(ix+1), rank, "x = %d", x)
+		}
+		ass.Equal(ix < len(keys), ceilingOk, "x = %d", x)
+		if ceilingOk {
+			ass.Equal(Int(keys[ix]), ceiling, "x = %d", x)
+		}
+	}
+}
+
+func collectInts(e Enumerable) []int {
+	var result []int
+	e.Foreach(func(c Comparable) {
+		result = append(result, GetInt(c))
+	})
+	return result
+}
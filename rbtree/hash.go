@@ -0,0 +1,89 @@
+package rbtree
+
+// This file contains all RB tree hashing methods implementations
+
+// KeyHasher calculates key's hash. Equal keys must have equal hashes
+type KeyHasher func(c Comparable) uint64
+
+// HashedTree represents red-black tree that maintains hash of every subtree.
+// It isn't a Merkle tree: subtree hash is additive multiset hash i.e. the sum (modulo 2^64)
+// of mixed key hashes. So it doesn't depend on tree shape and trees with the same keys
+// have the same hashes whatever insertion order was. The hash detects accidental differences
+// but it isn't collision resistant: crafted keys which hashes sum up to the same value
+// are easy to find, so it must not be used to detect malicious modifications
+type HashedTree interface {
+	RbTree
+
+	// RootHash gets the hash of all tree keys. Empty tree hash is zero
+	RootHash() uint64
+
+	// RangeHash gets the hash of all keys within [from, to] range in O(log n).
+	// So two parties can find differing key ranges by comparing range hashes
+	// and bisecting mismatched ranges. Zero is returned if any bound is nil
+	RangeHash(from Comparable, to Comparable) uint64
+}
+
+// NewHashed creates new empty Red-Black tree that maintains subtree hashes using KeyHasher specified
+func NewHashed(hasher KeyHasher) HashedTree {
+	tree := newRbTree()
+	tree.hasher = hasher
+	return tree
+}
+
+// Hash gets subtree hash including node itself. It's zero if tree has no KeyHasher
+func (n *Node) Hash() uint64 {
+	return n.hash
+}
+
+// RootHash gets the hash of all tree keys
+func (tree *rbTree) RootHash() uint64 {
+	if tree.root.isNil() {
+		return 0
+	}
+	return tree.root.hash
+}
+
+// RangeHash gets the hash of all keys within [from, to] range
+func (tree *rbTree) RangeHash(from Comparable, to Comparable) uint64 {
+	if from == nil || to == nil || to.Less(from) {
+		return 0
+	}
+	return tree.prefixHash(to, true) - tree.prefixHash(from, false)
+}
+
+// prefixHash gets the hash of all keys less than value (or equal to it if inclusive is true)
+func (tree *rbTree) prefixHash(value Comparable, inclusive bool) uint64 {
+	var h uint64
+	n := tree.root
+	for n.isNotNil() {
+		if n.key.Less(value) || (inclusive && !value.Less(n.key)) {
+			h += n.hash - n.right.hash
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return h
+}
+
+// keyHash gets key's hash that is summed into subtree hashes.
+// User hash is mixed so that simple hashes (like identity) don't collide on sums
+func (tree *rbTree) keyHash(c Comparable) uint64 {
+	if tree.hasher == nil {
+		return 0
+	}
+	return mix64(tree.hasher(c))
+}
+
+// ownHash gets node's own key hash
+func (n *Node) ownHash() uint64 {
+	return n.hash - n.left.hash - n.right.hash
+}
+
+// mix64 is splitmix64 finalizer
+func mix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
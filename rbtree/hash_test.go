@@ -0,0 +1,200 @@
+package rbtree
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func intHasher(c Comparable) uint64 {
+	return uint64(c.(Int))
+}
+
+func Test_RootHash_SameKeysDifferentOrder_Equal(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	a := NewHashed(intHasher)
+	b := NewHashed(intHasher)
+	keys := rand.Perm(200)
+
+	// Act
+	for i := range keys {
+		a.Insert(Int(keys[i]))
+		b.Insert(Int(keys[len(keys)-1-i]))
+	}
+
+	// Assert
+	ass.NotZero(a.RootHash())
+	ass.Equal(a.RootHash(), b.RootHash())
+	ass.True(validHashes(a.Root()))
+	ass.True(validHashes(b.Root()))
+}
+
+func Test_RootHash_DifferentKeys_NotEqual(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	a := NewHashed(intHasher)
+	b := NewHashed(intHasher)
+	for i := 0; i < 100; i++ {
+		a.Insert(Int(i))
+		b.Insert(Int(i))
+	}
+
+	// Act
+	b.Delete(Int(50))
+	b.Insert(Int(150))
+
+	// Assert
+	ass.NotEqual(a.RootHash(), b.RootHash())
+}
+
+func Test_RootHash_EmptyTreeOrWithoutHasher_Zero(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	hashed := NewHashed(intHasher)
+	tree := newIntTestTree()
+
+	// Act
+	hashed.Insert(Int(1))
+	hashed.Delete(Int(1))
+
+	// Assert
+	ass.Zero(hashed.RootHash())
+	ass.Zero(tree.Root().Hash())
+}
+
+func Test_RangeHash_DifferingRangeFound(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	a := NewHashed(intHasher)
+	b := NewHashed(intHasher)
+	for i := 0; i < 1000; i++ {
+		a.Insert(Int(i * 2))
+		b.Insert(Int(i * 2))
+	}
+	b.Delete(Int(1234))
+	b.Insert(Int(1235))
+
+	// Act
+	from, to := 0, 2000
+	for to-from > 1 {
+		mid := (from + to) / 2
+		if a.RangeHash(Int(from), Int(mid)) != b.RangeHash(Int(from), Int(mid)) {
+			to = mid
+		} else {
+			from = mid + 1
+		}
+	}
+
+	// Assert
+	ass.Equal(1234, from)
+}
+
+func Test_RangeHash_RandomRanges_EqualToSumOfRangeKeys(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := NewHashed(intHasher)
+	for i := 0; i < 300; i++ {
+		tree.Insert(Int(rand.Intn(100)))
+	}
+
+	for i := 0; i < 100; i++ {
+		from := Int(rand.Intn(110) - 5)
+		to := Int(rand.Intn(110) - 5)
+
+		// Act
+		h := tree.RangeHash(from, to)
+
+		// Assert
+		var expected uint64
+		NewWalkInorder(tree).Foreach(func(c Comparable) {
+			if !c.Less(from) && !to.Less(c) {
+				expected += mix64(intHasher(c))
+			}
+		})
+		ass.Equal(expected, h, "range [%d, %d]", from, to)
+	}
+}
+
+func Test_RangeHash_NilBounds_Zero(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := NewHashed(intHasher)
+	tree.Insert(Int(1))
+
+	// Act
+	h1 := tree.RangeHash(Int(0), nil)
+	h2 := tree.RangeHash(nil, Int(2))
+	h3 := tree.RangeHash(nil, nil)
+
+	// Assert
+	ass.Zero(h1)
+	ass.Zero(h2)
+	ass.Zero(h3)
+}
+
+func Test_Hash_RandomModifications_SubtreeHashesValid(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := NewHashed(intHasher)
+	reference := NewHashed(intHasher)
+
+	// Act
+	for i := 0; i < 2000; i++ {
+		k := Int(rand.Intn(300))
+		switch rand.Intn(4) {
+		case 0:
+			tree.Delete(k)
+		case 1:
+			if n, ok := tree.SearchNode(k); ok {
+				tree.UpdateKey(n, Int(rand.Intn(300)))
+			}
+		case 2:
+			tree.ReplaceOrInsert(k)
+		default:
+			tree.Insert(k)
+		}
+	}
+	NewWalkInorder(tree).Foreach(func(c Comparable) {
+		reference.Insert(c)
+	})
+
+	// Assert
+	ass.True(validHashes(tree.Root()))
+	ass.Equal(reference.RootHash(), tree.RootHash())
+}
+
+func Test_Hash_UnmarshalBinary_HashesBuilt(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	src := NewHashed(intHasher)
+	for i := 0; i < 100; i++ {
+		src.Insert(Int(i))
+	}
+	data, _ := src.(*rbTree).MarshalBinary()
+	dst := NewHashed(intHasher)
+
+	// Act
+	err := dst.(*rbTree).UnmarshalBinary(data)
+
+	// Assert
+	ass.NoError(err)
+	ass.True(validHashes(dst.Root()))
+	ass.Equal(src.RootHash(), dst.RootHash())
+}
+
+// validHashes checks that every subtree hash is the sum of its keys hashes
+func validHashes(n *Node) bool {
+	_, ok := subtreeHash(n)
+	return ok
+}
+
+func subtreeHash(n *Node) (uint64, bool) {
+	if n.isNil() {
+		return 0, true
+	}
+	l, lok := subtreeHash(n.left)
+	r, rok := subtreeHash(n.right)
+	h := l + r + mix64(intHasher(n.key))
+	return h, lok && rok && h == n.hash
+}
@@ -22,6 +22,14 @@ type RbTree interface {
 	// It returns true if nodes was successfully deleted otherwise false
 	DeleteAll(c Comparable) bool
 
+	// DeleteNode deletes Node specified from Red-black tree. The Node must be obtained from the same tree
+	// (by SearchNode, EqualRange etc.). It returns true if Node was successfully deleted otherwise false
+	DeleteNode(n *Node) bool
+
+	// UpdateKey changes the key of Node specified and moves the Node to the new position if necessary
+	// without allocating new Node. It returns true if key was successfully updated otherwise false
+	UpdateKey(n *Node, key Comparable) bool
+
 	// Search searches value specified within search tree
 	Search(value Comparable) (Comparable, bool)
 
@@ -32,11 +40,50 @@ type RbTree interface {
 	Ceiling(value Comparable) (Comparable, bool)
 
 	// SearchAll searches all values with the same key as specified within search tree
+	//
+	// Deprecated: use EqualRange or NewEqualRange that don't allocate a slice
 	SearchAll(value Comparable) []Comparable
 
+	// EqualRange searches the first and the last nodes which keys are equal to value specified
+	// and returns them together with the number of such nodes. It runs in O(log n) using subtree sizes.
+	// If nothing found both nodes are nil and count is zero
+	EqualRange(value Comparable) (first *Node, last *Node, count int64)
+
 	// SearchNode searches *Node which key is equals value specified
 	SearchNode(value Comparable) (*Node, bool)
 
+	// SearchFrom searches *Node which key is equals value specified starting from the node specified (finger).
+	// The finger must be obtained from the same tree (by SearchNode, EqualRange etc.) otherwise
+	// the result is undefined. It walks up from the finger to the lowest common ancestor of the finger
+	// and the result and back down so the search costs O(height of the lowest common ancestor).
+	// It's cheap for sequential access when the result is close to the finger in the same subtree
+	// but it's O(log n) in the worst case e.g. when the finger and the result are on opposite sides of the root.
+	// If finger is nil or already deleted the search starts from the root
+	SearchFrom(finger *Node, value Comparable) (*Node, bool)
+
+	// FloorFrom searches *Node with the greatest key lesser than or equal to value starting from the finger specified.
+	// The finger requirements and search cost are the same as for SearchFrom
+	FloorFrom(finger *Node, value Comparable) (*Node, bool)
+
+	// CeilingFrom searches *Node with the smallest key larger than or equal to value starting from the finger specified.
+	// The finger requirements and search cost are the same as for SearchFrom
+	CeilingFrom(finger *Node, value Comparable) (*Node, bool)
+
+	// FindFirst searches the first *Node in ascending order for which monotone predicate specified becomes true.
+	// The predicate must return false for some (possibly empty) prefix of the keys and true for the rest.
+	// It runs in O(log n)
+	FindFirst(pred KeyPredicate) (*Node, bool)
+
+	// FindLast searches the last *Node in ascending order for which monotone predicate specified is true.
+	// The predicate must return true for some (possibly empty) prefix of the keys and false for the rest.
+	// It runs in O(log n)
+	FindLast(pred KeyPredicate) (*Node, bool)
+
+	// PartitionPoint gets the number of nodes for which monotone predicate specified is false
+	// i.e. the rank of the boundary node found by FindFirst minus one. If the predicate is
+	// false for all nodes the tree length is returned. It runs in O(log n)
+	PartitionPoint(pred KeyPredicate) int64
+
 	// Minimum gets tree's min element
 	Minimum() *Node
 
@@ -47,6 +94,43 @@ type RbTree interface {
 	// IMPORTANT: numeration starts from 1 not from 0
 	OrderStatisticSelect(i int64) (*Node, bool)
 
+	// Quantile gets the key at quantile q (within [0, 1] range) using nearest rank method
+	// i.e. the key with rank ceil(q * Len()) but at least 1.
+	// ErrEmptyTree or ErrQuantileOutOfRange is returned if quantile cannot be calculated
+	Quantile(q float64) (Comparable, error)
+
+	// Median gets the median key. For even length the lower median is returned
+	Median() (Comparable, error)
+
+	// Percentiles gets keys at all quantiles (within [0, 1] range) specified
+	Percentiles(qs ...float64) ([]Comparable, error)
+
+	// InterpolatedQuantile gets quantile q (within [0, 1] range) of numeric keys (integer and float key types)
+	// using linear interpolation between the closest ranks.
+	// ErrNotNumeric is returned if keys aren't numeric
+	InterpolatedQuantile(q float64) (float64, error)
+
+	// Stats calculates tree statistics (node count, height, black height, leaf depths,
+	// depth histogram and approximate memory footprint) in a single pass
+	Stats() TreeStats
+
+	// Subscribe registers handler that is called on every tree modification event of kinds specified
+	// (for example EventMutations or EventAll). It returns function that cancels the subscription
+	Subscribe(handler EventHandler, events EventKind) (unsubscribe func())
+
+	// Begin starts journaling tree modifications. Calling Begin within a transaction
+	// creates nested savepoint
+	Begin()
+
+	// Commit keeps modifications made since the latest Begin and releases its savepoint.
+	// The journal is discarded when the outermost transaction committed.
+	// ErrNoTransaction is returned if there is no transaction in progress
+	Commit() error
+
+	// Rollback undoes modifications made since the latest Begin in reverse order restoring
+	// previous tree content. ErrNoTransaction is returned if there is no transaction in progress
+	Rollback() error
+
 	// Root gets tree root Node
 	Root() *Node
 }
@@ -70,6 +154,23 @@ type Enumerable interface {
 	Foreach(callback NodeAction)
 }
 
+// DepthEnumerable represents depth-aware tree walk enumeration interface
+type DepthEnumerable interface {
+	// Iterator gets underlying DepthIterator
+	Iterator() DepthIterator
+
+	// Foreach enumerates tree and calls the callback for
+	// every node in the tree with its depth and color.
+	Foreach(callback DepthAction)
+}
+
+// DepthAction defines function prototype that used by depth-aware walks.
+// The root depth is zero.
+type DepthAction func(n *Node, depth int, color Color)
+
+// KeyPredicate defines monotone predicate function prototype that used by binary search methods
+type KeyPredicate func(Comparable) bool
+
 // NodeAction defines function prototype that used by an iteration method to iterate over portions of
 // the tree.
 type NodeAction func(Comparable)
@@ -91,3 +192,14 @@ type Iterator interface {
 	// dependent.
 	Next() bool
 }
+
+// DepthIterator is a node iterator that also provides current node and its depth.
+type DepthIterator interface {
+	Iterator
+
+	// Node gets current Node
+	Node() *Node
+
+	// Depth gets current Node depth. The root depth is zero
+	Depth() int
+}
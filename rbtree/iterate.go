@@ -10,16 +10,23 @@ type iterator struct {
 	curr *Node
 }
 
+type depthEnumerable struct{ it DepthIterator }
+
 type walk struct {
 	iterator
-	stack []*Node
+	stack  []*Node
+	depths []int
+	depth  int
 }
 
 type walkPreorder struct{ walk }
 
+type walkLevelOrder struct{ walk }
+
 type walkInorder struct {
 	walk
-	p *Node
+	p      *Node
+	pDepth int
 }
 
 type walkPostorder struct {
@@ -31,6 +38,12 @@ type ascend struct{ ordered }
 
 type descend struct{ ordered }
 
+type prefixScan struct {
+	iterator
+	next   *Node
+	prefix PrefixComparable
+}
+
 type ordered struct {
 	iterator
 	next *Node
@@ -75,6 +88,41 @@ func NewWalkPostorder(t RbTree) Enumerable {
 	return e
 }
 
+// NewWalkLevelOrder creates Enumerable that walks tree in level order (breadth-first)
+// i.e. all nodes of the depth before all nodes of the next depth from left to right
+func NewWalkLevelOrder(t RbTree) Enumerable {
+	e := &walkLevelOrder{
+		walk: newWalk(t),
+	}
+
+	e.it = e
+	return e
+}
+
+// NewDepthWalkInorder creates DepthEnumerable that walks tree inorder (left, node, right)
+// providing every node's depth and color
+func NewDepthWalkInorder(t RbTree) DepthEnumerable {
+	return &depthEnumerable{it: NewWalkInorder(t).(*walkInorder)}
+}
+
+// NewDepthWalkPreorder creates DepthEnumerable that walks tree preorder (node, left, right)
+// providing every node's depth and color
+func NewDepthWalkPreorder(t RbTree) DepthEnumerable {
+	return &depthEnumerable{it: NewWalkPreorder(t).(*walkPreorder)}
+}
+
+// NewDepthWalkPostorder creates DepthEnumerable that walks tree postorder (left, right, node)
+// providing every node's depth and color
+func NewDepthWalkPostorder(t RbTree) DepthEnumerable {
+	return &depthEnumerable{it: NewWalkPostorder(t).(*walkPostorder)}
+}
+
+// NewDepthWalkLevelOrder creates DepthEnumerable that walks tree in level order (breadth-first)
+// providing every node's depth and color
+func NewDepthWalkLevelOrder(t RbTree) DepthEnumerable {
+	return &depthEnumerable{it: NewWalkLevelOrder(t).(*walkLevelOrder)}
+}
+
 // NewAscend creates Enumerable that walks tree in ascending order
 func NewAscend(t RbTree) Enumerable {
 	return NewWalkInorder(t)
@@ -118,6 +166,39 @@ func NewOpenAscendRange(t RbTree, from, to Comparable) Enumerable {
 	return e
 }
 
+// NewEqualRange creates Enumerable that walks all nodes which keys are equal to value specified
+// in ascending order
+func NewEqualRange(t RbTree, value Comparable) Enumerable {
+	ordered := newOrdered(t)
+	e := &ascend{ordered: ordered}
+	e.it = e
+
+	first, _, count := e.tree.EqualRange(value)
+	if count > 0 {
+		e.next = first
+		e.to = value
+	}
+
+	return e
+}
+
+// NewPrefixScan creates Enumerable that walks all keys starting with the prefix specified
+// in ascending order. Tree keys must have the same type as the prefix.
+// The first key is found in O(log n)
+func NewPrefixScan(t RbTree, prefix PrefixComparable) Enumerable {
+	e := &prefixScan{
+		iterator: iterator{tree: t},
+		prefix:   prefix,
+	}
+	e.it = e
+
+	e.next, _ = t.FindFirst(func(c Comparable) bool {
+		return !c.Less(prefix) || c.(PrefixComparable).HasPrefix(prefix)
+	})
+
+	return e
+}
+
 // NewDescend creates Enumerable that walks tree in descending order
 func NewDescend(t RbTree) Enumerable {
 	e := newDescend(t)
@@ -169,18 +250,19 @@ func (i *walkInorder) Next() bool {
 	for len(i.stack) > 0 {
 		if i.p.isNotNil() {
 			i.p = i.p.left
+			i.pDepth++
 			if i.p.isNotNil() {
-				i.stack = append(i.stack, i.p)
+				i.push(i.p, i.pDepth)
 			}
 		} else {
-			top := len(i.stack) - 1
-			i.p = i.stack[top]
+			i.p, i.pDepth = i.pop()
 			i.curr = i.p
-			i.stack = i.stack[:top]
+			i.depth = i.pDepth
 			i.p = i.p.right
+			i.pDepth++
 
 			if i.p.isNotNil() {
-				i.stack = append(i.stack, i.p)
+				i.push(i.p, i.pDepth)
 			}
 			return true
 		}
@@ -191,17 +273,16 @@ func (i *walkInorder) Next() bool {
 
 func (i *walkPreorder) Next() bool {
 	if len(i.stack) > 0 {
-		top := len(i.stack) - 1
-		p := i.stack[top]
+		p, depth := i.pop()
 		i.curr = p
-		i.stack = i.stack[:top]
+		i.depth = depth
 
 		if p.right.isNotNil() {
-			i.stack = append(i.stack, p.right)
+			i.push(p.right, depth+1)
 		}
 
 		if p.left.isNotNil() {
-			i.stack = append(i.stack, p.left)
+			i.push(p.left, depth+1)
 		}
 
 		return true
@@ -214,20 +295,45 @@ func (i *walkPostorder) Next() bool {
 	for len(i.stack) > 0 {
 		top := len(i.stack) - 1
 		next := i.stack[top]
+		depth := i.depths[top]
 
 		if next.right == i.p || next.left == i.p || (next.right.isNil() && next.left.isNil()) {
-			i.stack = i.stack[:top]
+			i.pop()
 			i.curr = next
+			i.depth = depth
 			i.p = next
 			return true
 		}
 
 		if next.right.isNotNil() {
-			i.stack = append(i.stack, next.right)
+			i.push(next.right, depth+1)
 		}
 		if next.left.isNotNil() {
-			i.stack = append(i.stack, next.left)
+			i.push(next.left, depth+1)
+		}
+	}
+
+	return false
+}
+
+func (i *walkLevelOrder) Next() bool {
+	if len(i.stack) > 0 {
+		p := i.stack[0]
+		depth := i.depths[0]
+		i.stack = i.stack[1:]
+		i.depths = i.depths[1:]
+		i.curr = p
+		i.depth = depth
+
+		if p.left.isNotNil() {
+			i.push(p.left, depth+1)
 		}
+
+		if p.right.isNotNil() {
+			i.push(p.right, depth+1)
+		}
+
+		return true
 	}
 
 	return false
@@ -242,6 +348,15 @@ func (i *ascend) Next() bool {
 	return result
 }
 
+func (i *prefixScan) Next() bool {
+	result := i.next.isNotNil() && i.next.key.(PrefixComparable).HasPrefix(i.prefix)
+	if result {
+		i.curr = i.next
+		i.next = i.curr.Successor()
+	}
+	return result
+}
+
 func (i *descend) Next() bool {
 	result := i.next.isNotNil() && !i.next.key.Less(i.to)
 	if result {
@@ -261,18 +376,48 @@ func (e *enumerable) Foreach(callback NodeAction) {
 
 func (e *enumerable) Iterator() Iterator { return e.it }
 
+// Foreach does tree iteration and calls the callback for
+// every node in the tree with its depth and color.
+func (e *depthEnumerable) Foreach(callback DepthAction) {
+	for e.it.Next() {
+		n := e.it.Node()
+		callback(n, e.it.Depth(), n.Color())
+	}
+}
+
+func (e *depthEnumerable) Iterator() DepthIterator { return e.it }
+
 func (i *iterator) Current() Comparable { return i.curr.key }
 
+func (w *walk) Node() *Node { return w.curr }
+
+func (w *walk) Depth() int { return w.depth }
+
+func (w *walk) push(n *Node, depth int) {
+	w.stack = append(w.stack, n)
+	w.depths = append(w.depths, depth)
+}
+
+func (w *walk) pop() (*Node, int) {
+	top := len(w.stack) - 1
+	n := w.stack[top]
+	depth := w.depths[top]
+	w.stack = w.stack[:top]
+	w.depths = w.depths[:top]
+	return n, depth
+}
+
 func newWalk(t RbTree) walk {
 	it := iterator{tree: t}
 
 	w := walk{
 		iterator: it,
 		stack:    make([]*Node, 0),
+		depths:   make([]int, 0),
 	}
 
 	if t.Len() > 0 {
-		w.stack = append(w.stack, t.Root())
+		w.push(t.Root(), 0)
 	}
 
 	return w
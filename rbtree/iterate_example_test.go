@@ -58,6 +58,46 @@ func ExampleNewWalkPostorder() {
 	// 6
 }
 
+func ExampleNewWalkLevelOrder() {
+	tree := New()
+
+	tree.Insert(Int(6))
+	tree.Insert(Int(18))
+	tree.Insert(Int(3))
+	tree.Insert(Int(20))
+
+	it := NewWalkLevelOrder(tree)
+
+	it.Foreach(func(n Comparable) {
+		fmt.Println(n)
+	})
+	// Output:
+	// 6
+	// 3
+	// 18
+	// 20
+}
+
+func ExampleNewDepthWalkLevelOrder() {
+	tree := New()
+
+	tree.Insert(Int(6))
+	tree.Insert(Int(18))
+	tree.Insert(Int(3))
+	tree.Insert(Int(20))
+
+	it := NewDepthWalkLevelOrder(tree)
+
+	it.Foreach(func(n *Node, depth int, color Color) {
+		fmt.Println(n.Key(), depth, color)
+	})
+	// Output:
+	// 6 0 black
+	// 3 1 black
+	// 18 1 black
+	// 20 2 red
+}
+
 func ExampleNewAscend() {
 	tree := New()
 
@@ -161,3 +201,77 @@ func ExampleNewOpenDescendRange() {
 	// 18
 	// 6
 }
+
+func ExampleNewEqualRange() {
+	tree := New()
+
+	tree.Insert(Int(6))
+	tree.Insert(Int(18))
+	tree.Insert(Int(6))
+	tree.Insert(Int(3))
+
+	it := NewEqualRange(tree, Int(6))
+
+	it.Foreach(func(n Comparable) {
+		fmt.Println(n)
+	})
+	// Output:
+	// 6
+	// 6
+}
+
+func ExamplePartition() {
+	tree := New()
+
+	for i := 1; i <= 6; i++ {
+		tree.Insert(Int(i))
+	}
+
+	for i, chunk := range Partition(tree, 3) {
+		chunk.Foreach(func(n Comparable) {
+			fmt.Println(i, n)
+		})
+	}
+	// Output:
+	// 0 1
+	// 0 2
+	// 1 3
+	// 1 4
+	// 2 5
+	// 2 6
+}
+
+func ExampleParallelMap() {
+	tree := New()
+
+	tree.Insert(Int(6))
+	tree.Insert(Int(18))
+	tree.Insert(Int(3))
+
+	squares := ParallelMap(tree, 2, func(c Comparable) int {
+		return GetInt(c) * GetInt(c)
+	})
+
+	fmt.Println(squares)
+	// Output:
+	// [9 36 324]
+}
+
+func ExampleNewPrefixScan() {
+	tree := New()
+
+	tree.Insert(NewString("user:42:name"))
+	tree.Insert(NewString("user:420:name"))
+	tree.Insert(NewString("user:42:age"))
+	tree.Insert(NewString("user:41:name"))
+
+	prefix := NewString("user:42:").(*String)
+	NewPrefixScan(tree, prefix).Foreach(func(n Comparable) {
+		fmt.Println(n)
+	})
+	fmt.Println(CountPrefix(tree, prefix))
+	// Output:
+	// user:42:age
+	// user:42:name
+	// 2
+}
@@ -36,6 +36,10 @@ func Test_Foreach(t *testing.T) {
 		{"ascend range val to nil", NewAscendRange(tree, Int(6), nil), []int{}},
 		{"ascend range nil to nil", NewAscendRange(tree, nil, nil), []int{}},
 
+		{"equal range found", NewEqualRange(tree, Int(9)), []int{9}},
+		{"equal range not found", NewEqualRange(tree, Int(10)), []int{}},
+		{"equal range nil", NewEqualRange(tree, nil), []int{}},
+
 		{"descend normal", NewDescend(tree), []int{20, 18, 17, 15, 13, 9, 7, 6, 4, 3, 2}},
 
 		{"open descend range from > max", NewOpenDescendRange(tree, Int(30), Int(17)), []int{20, 18, 17}},
@@ -63,12 +67,14 @@ func Test_Foreach(t *testing.T) {
 		{"inorder normal", NewWalkInorder(tree), []int{2, 3, 4, 6, 7, 9, 13, 15, 17, 18, 20}},
 		{"preorder normal", NewWalkPreorder(tree), []int{6, 3, 2, 4, 15, 9, 7, 13, 18, 17, 20}},
 		{"postorder normal", NewWalkPostorder(tree), []int{2, 4, 3, 7, 13, 9, 17, 20, 18, 15, 6}},
+		{"level order normal", NewWalkLevelOrder(tree), []int{6, 3, 15, 2, 4, 9, 18, 7, 13, 17, 20}},
 
 		{"ascend empty", NewAscend(New()), []int{}},
 		{"descend empty", NewDescend(New()), []int{}},
 		{"inorder empty", NewWalkInorder(New()), []int{}},
 		{"preorder empty", NewWalkPreorder(New()), []int{}},
 		{"postorder empty", NewWalkPostorder(New()), []int{}},
+		{"level order empty", NewWalkLevelOrder(New()), []int{}},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -100,6 +106,10 @@ func Test_Foreach_SpecialCases(t *testing.T) {
 		{"ascend all eq one", NewAscend, []int{2}, []int{2}},
 		{"ascend all eq zero", NewAscend, []int{}, []int{}},
 
+		{"equal range all eq three", equalRangeOf2, []int{2, 2, 2}, []int{2, 2, 2}},
+		{"equal range with others", equalRangeOf2, []int{1, 2, 3, 2, 0, 2}, []int{2, 2, 2}},
+		{"equal range all not eq", equalRangeOf2, []int{1, 3}, []int{}},
+
 		{"descend all eq three", NewDescend, []int{2, 2, 2}, []int{2, 2, 2}},
 		{"descend all eq two", NewDescend, []int{2, 2}, []int{2, 2}},
 		{"descend all not eq two", NewDescend, []int{1, 2}, []int{2, 1}},
@@ -123,6 +133,11 @@ func Test_Foreach_SpecialCases(t *testing.T) {
 		{"postorder all not eq two", NewWalkPostorder, []int{1, 2}, []int{2, 1}},
 		{"postorder all eq one", NewWalkPostorder, []int{2}, []int{2}},
 		{"postorder all eq zero", NewWalkPostorder, []int{}, []int{}},
+
+		{"level order all eq three", NewWalkLevelOrder, []int{2, 2, 2}, []int{2, 2, 2}},
+		{"level order all not eq two", NewWalkLevelOrder, []int{1, 2}, []int{1, 2}},
+		{"level order all eq one", NewWalkLevelOrder, []int{2}, []int{2}},
+		{"level order all eq zero", NewWalkLevelOrder, []int{}, []int{}},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -161,6 +176,7 @@ func Test_IteratorsWithInterruption_Normal(t *testing.T) {
 		{"inorder", NewWalkInorder(tree), []int{2, 3, 4, 6}},
 		{"preorder", NewWalkPreorder(tree), []int{6, 3, 2, 4}},
 		{"postorder", NewWalkPostorder(tree), []int{2, 4, 3}},
+		{"level order", NewWalkLevelOrder(tree), []int{6, 3}},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -184,6 +200,75 @@ func Test_IteratorsWithInterruption_Normal(t *testing.T) {
 	}
 }
 
+func Test_DepthWalks_DepthsAsExpected(t *testing.T) {
+	tree := newIntTestTree()
+	var tests = []struct {
+		name     string
+		enum     DepthEnumerable
+		expected []int
+		depths   []int
+	}{
+		{"inorder", NewDepthWalkInorder(tree), []int{2, 3, 4, 6, 7, 9, 13, 15, 17, 18, 20}, []int{2, 1, 2, 0, 3, 2, 3, 1, 3, 2, 3}},
+		{"preorder", NewDepthWalkPreorder(tree), []int{6, 3, 2, 4, 15, 9, 7, 13, 18, 17, 20}, []int{0, 1, 2, 2, 1, 2, 3, 3, 2, 3, 3}},
+		{"postorder", NewDepthWalkPostorder(tree), []int{2, 4, 3, 7, 13, 9, 17, 20, 18, 15, 6}, []int{2, 2, 1, 3, 3, 2, 3, 3, 2, 1, 0}},
+		{"level order", NewDepthWalkLevelOrder(tree), []int{6, 3, 15, 2, 4, 9, 18, 7, 13, 17, 20}, []int{0, 1, 1, 2, 2, 2, 2, 3, 3, 3, 3}},
+		{"inorder empty", NewDepthWalkInorder(New()), []int{}, []int{}},
+		{"preorder empty", NewDepthWalkPreorder(New()), []int{}, []int{}},
+		{"postorder empty", NewDepthWalkPostorder(New()), []int{}, []int{}},
+		{"level order empty", NewDepthWalkLevelOrder(New()), []int{}, []int{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			result := make([]int, 0)
+			depths := make([]int, 0)
+
+			// Act
+			test.enum.Foreach(func(n *Node, depth int, color Color) {
+				result = append(result, GetInt(n.Key()))
+				depths = append(depths, depth)
+				ass.Equal(n.Color(), color)
+			})
+
+			// Assert
+			ass.Equal(test.expected, result)
+			ass.Equal(test.depths, depths)
+		})
+	}
+}
+
+func Test_DepthWalkIterator_NodeAndCurrentConsistent(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTestTree()
+	it := NewDepthWalkLevelOrder(tree).Iterator()
+	colors := make(map[Color]int)
+
+	// Act
+	for it.Next() {
+		ass.Equal(it.Current(), it.Node().Key())
+		colors[it.Node().Color()]++
+	}
+
+	// Assert
+	ass.Equal(Black, tree.Root().Color())
+	ass.Equal(int(tree.Len()), colors[Black]+colors[Red])
+}
+
+func Test_ColorString(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+
+	// Act
+	r := Red.String()
+	b := Black.String()
+
+	// Assert
+	ass.Equal("red", r)
+	ass.Equal("black", b)
+}
+
 func Test_InorderWalkString_AllElementsAscending(t *testing.T) {
 	// Arrange
 	ass := assert.New(t)
@@ -199,3 +284,7 @@ func Test_InorderWalkString_AllElementsAscending(t *testing.T) {
 	// Assert
 	ass.Equal([]string{"abc", "amd", "cisco", "do", "fake", "intel", "it", "let", "microsoft", "russia", "usa", "xxx", "yyy", "zen"}, result)
 }
+
+func equalRangeOf2(t RbTree) Enumerable {
+	return NewEqualRange(t, Int(2))
+}
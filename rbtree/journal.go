@@ -0,0 +1,84 @@
+package rbtree
+
+import "errors"
+
+// This file contains all RB tree transaction (undo journal) methods implementations
+
+// ErrNoTransaction is returned by Commit or Rollback when no transaction was begun
+var ErrNoTransaction = errors.New("no transaction in progress")
+
+// journalEntry describes single tree modification that can be undone
+type journalEntry struct {
+	// kind is one of EventInsert, EventDelete, EventUpdate or EventReset
+	kind EventKind
+
+	// node is the node inserted, deleted or updated. For EventReset it's the previous root
+	node *Node
+
+	// key is the node key before update
+	key Comparable
+}
+
+// Begin starts journaling tree modifications. If a transaction is already in progress
+// nested savepoint is created so that Rollback undoes modifications made since this Begin only
+func (tree *rbTree) Begin() {
+	tree.savepoints = append(tree.savepoints, len(tree.journal))
+}
+
+// Commit releases the latest savepoint keeping its modifications.
+// When the outermost transaction is committed the journal is discarded
+func (tree *rbTree) Commit() error {
+	if len(tree.savepoints) == 0 {
+		return ErrNoTransaction
+	}
+	tree.savepoints = tree.savepoints[:len(tree.savepoints)-1]
+	if len(tree.savepoints) == 0 {
+		tree.journal = nil
+	}
+	return nil
+}
+
+// Rollback undoes all modifications made since the latest Begin in reverse order
+// and releases the savepoint. Nodes deleted are reinserted so nodes obtained
+// before modifications stay valid. Observers are notified about every undo step
+func (tree *rbTree) Rollback() error {
+	if len(tree.savepoints) == 0 {
+		return ErrNoTransaction
+	}
+	top := len(tree.savepoints) - 1
+	savepoint := tree.savepoints[top]
+	tree.savepoints = tree.savepoints[:top]
+
+	entries := tree.journal[savepoint:]
+	tree.journal = tree.journal[:savepoint]
+	for i := len(entries) - 1; i >= 0; i-- {
+		tree.undo(entries[i])
+	}
+	if top == 0 {
+		tree.journal = nil
+	}
+	return nil
+}
+
+func (tree *rbTree) undo(e journalEntry) {
+	switch e.kind {
+	case EventInsert:
+		tree.delete(e.node)
+		tree.notify(EventDelete, e.node.key, nil, e.node)
+	case EventDelete:
+		tree.insert(e.node)
+		tree.notify(EventInsert, e.node.key, nil, e.node)
+	case EventUpdate:
+		tree.updateKey(e.node, e.key)
+	case EventReset:
+		tree.root = e.node
+		tree.notify(EventReset, nil, nil, nil)
+	}
+}
+
+// record appends modification to the journal if a transaction is in progress
+func (tree *rbTree) record(kind EventKind, n *Node, key Comparable) {
+	if len(tree.savepoints) > 0 {
+		tree.journal = append(tree.journal, journalEntry{kind: kind, node: n, key: key})
+	}
+}
@@ -0,0 +1,142 @@
+package rbtree
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func Test_Rollback_AllModificationsUndone(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTestTree()
+	before := ascendInts(tree)
+	n, _ := tree.SearchNode(Int(7))
+
+	// Act
+	tree.Begin()
+	tree.Insert(Int(100))
+	tree.Delete(Int(6))
+	tree.ReplaceOrInsert(Int(18))
+	tree.UpdateKey(n, Int(1))
+	tree.DeleteAll(Int(20))
+	err := tree.Rollback()
+
+	// Assert
+	ass.NoError(err)
+	ass.Equal(before, ascendInts(tree))
+	ass.True(isValidRbTree(tree))
+	ass.Equal(Int(7), n.Key())
+	ass.True(tree.DeleteNode(n))
+}
+
+func Test_Commit_ModificationsKept(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTestTree()
+
+	// Act
+	tree.Begin()
+	tree.Insert(Int(100))
+	tree.Delete(Int(6))
+	err := tree.Commit()
+
+	// Assert
+	ass.NoError(err)
+	ass.Equal([]int{2, 3, 4, 7, 9, 13, 15, 17, 18, 20, 100}, ascendInts(tree))
+	ass.ErrorIs(tree.Rollback(), ErrNoTransaction)
+	ass.ErrorIs(tree.Commit(), ErrNoTransaction)
+}
+
+func Test_Rollback_NestedSavepoints(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTree([]int{1, 2, 3})
+
+	// Act
+	tree.Begin()
+	tree.Insert(Int(4))
+	tree.Begin()
+	tree.Insert(Int(5))
+	tree.Delete(Int(1))
+	_ = tree.Rollback()
+	afterInner := ascendInts(tree)
+	tree.Begin()
+	tree.Insert(Int(6))
+	_ = tree.Commit()
+	afterCommit := ascendInts(tree)
+	_ = tree.Rollback()
+
+	// Assert
+	ass.Equal([]int{1, 2, 3, 4}, afterInner)
+	ass.Equal([]int{1, 2, 3, 4, 6}, afterCommit)
+	ass.Equal([]int{1, 2, 3}, ascendInts(tree))
+}
+
+func Test_Rollback_UnmarshalBinary_PreviousContentRestored(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTree([]int{1, 2, 3})
+	data, _ := newIntTestTree().(*rbTree).MarshalBinary()
+
+	// Act
+	tree.Begin()
+	_ = tree.(*rbTree).UnmarshalBinary(data)
+	tree.Insert(Int(50))
+	_ = tree.Rollback()
+
+	// Assert
+	ass.Equal([]int{1, 2, 3}, ascendInts(tree))
+	ass.True(isValidRbTree(tree))
+}
+
+func Test_Rollback_RandomModifications_ContentRestored(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := NewHashed(intHasher)
+	for i := 0; i < 200; i++ {
+		tree.Insert(Int(rand.Intn(100)))
+	}
+	before := ascendInts(tree)
+	hash := tree.RootHash()
+
+	// Act
+	tree.Begin()
+	for i := 0; i < 500; i++ {
+		k := Int(rand.Intn(100))
+		switch rand.Intn(3) {
+		case 0:
+			tree.Delete(k)
+		case 1:
+			if n, ok := tree.SearchNode(k); ok {
+				tree.UpdateKey(n, Int(rand.Intn(100)))
+			}
+		default:
+			tree.Insert(k)
+		}
+	}
+	_ = tree.Rollback()
+
+	// Assert
+	ass.Equal(before, ascendInts(tree))
+	ass.Equal(hash, tree.RootHash())
+	ass.True(isValidRbTree(tree))
+	ass.True(validHashes(tree.Root()))
+}
+
+func Test_Rollback_ObserversNotified(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTree([]int{1, 2})
+	var kinds []EventKind
+	tree.Begin()
+	tree.Insert(Int(3))
+	tree.Delete(Int(1))
+	tree.Subscribe(func(e Event) { kinds = append(kinds, e.Kind) }, EventMutations)
+
+	// Act
+	_ = tree.Rollback()
+
+	// Assert
+	ass.Equal([]EventKind{EventInsert, EventDelete}, kinds)
+}
@@ -0,0 +1,198 @@
+package rbtree
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// This file contains RB tree JSON encoding implementation
+
+// ErrUnknownKeyType is returned when JSON keys cannot be decoded because key type
+// isn't registered or cannot be inferred
+var ErrUnknownKeyType = errors.New("unknown key type")
+
+var keyTypes = struct {
+	sync.RWMutex
+	types map[string]reflect.Type
+}{
+	types: map[string]reflect.Type{
+		"int":     reflect.TypeOf(Int(0)),
+		"int64":   reflect.TypeOf(Int64(0)),
+		"string":  reflect.TypeOf(NewString("")),
+		"int8":    reflect.TypeOf(Int8(0)),
+		"int16":   reflect.TypeOf(Int16(0)),
+		"int32":   reflect.TypeOf(Int32(0)),
+		"uint":    reflect.TypeOf(Uint(0)),
+		"uint8":   reflect.TypeOf(Uint8(0)),
+		"uint16":  reflect.TypeOf(Uint16(0)),
+		"uint32":  reflect.TypeOf(Uint32(0)),
+		"uint64":  reflect.TypeOf(Uint64(0)),
+		"float32": reflect.TypeOf(Float32(0)),
+		"float64": reflect.TypeOf(Float64(0)),
+		"time":    reflect.TypeOf(Time{}),
+		"bytes":   reflect.TypeOf(Bytes{}),
+		"bool":    reflect.TypeOf(Bool(false)),
+	},
+}
+
+// RegisterKeyType registers Comparable type of the prototype specified under the name
+// so that trees created by NewWithKeyType can decode JSON into keys of this type.
+// Built-in types are registered as "int" (Int), "int8", "int16", "int32", "int64", "uint", "uint8", "uint16",
+// "uint32", "uint64", "float32", "float64" (types with the same names capitalized), "string" (*String),
+// "time" (Time), "bytes" (Bytes) and "bool" (Bool). Registering the same name replaces its type
+func RegisterKeyType(name string, prototype Comparable) {
+	keyTypes.Lock()
+	defer keyTypes.Unlock()
+	keyTypes.types[name] = reflect.TypeOf(prototype)
+}
+
+// NewWithKeyType creates new empty Red-Black tree that decodes JSON into keys of the type
+// registered under the name specified
+func NewWithKeyType(name string) (RbTree, error) {
+	keyTypes.RLock()
+	defer keyTypes.RUnlock()
+	t, ok := keyTypes.types[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKeyType, name)
+	}
+	tree := newRbTree()
+	tree.keyType = t
+	return tree, nil
+}
+
+// MarshalJSON implements json.Marshaler interface. The tree is encoded as JSON array of keys
+// in ascending order
+func (tree *rbTree) MarshalJSON() ([]byte, error) {
+	keys := make([]Comparable, 0, tree.Len())
+	for n := tree.Minimum(); n != nil; n = n.Successor() {
+		keys = append(keys, n.key)
+	}
+	return json.Marshal(keys)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface. It replaces tree content with keys decoded
+// from JSON array. Keys are decoded into the type set by NewWithKeyType or, if not set,
+// JSON strings are decoded as *String and numbers as Int
+func (tree *rbTree) UnmarshalJSON(data []byte) error {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return err
+	}
+
+	t := tree.keyType
+	if t == nil && len(raws) > 0 {
+		t = inferKeyType(raws[0])
+		if t == nil {
+			return ErrUnknownKeyType
+		}
+	}
+
+	keys := make([]Comparable, len(raws))
+	for i, raw := range raws {
+		v := reflect.New(t)
+		if err := json.Unmarshal(raw, v.Interface()); err != nil {
+			return err
+		}
+		e := v.Elem()
+		if e.Kind() == reflect.Pointer && e.IsNil() {
+			return fmt.Errorf("%w: null key", ErrInvalidKey)
+		}
+		keys[i] = e.Interface().(Comparable)
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].Less(keys[j]) })
+	tree.build(keys)
+	return nil
+}
+
+func inferKeyType(raw json.RawMessage) reflect.Type {
+	raw = bytes.TrimSpace(raw)
+	switch {
+	case len(raw) == 0:
+		return nil
+	case raw[0] == '"':
+		return reflect.TypeOf(NewString(""))
+	case raw[0] == '-' || (raw[0] >= '0' && raw[0] <= '9'):
+		return reflect.TypeOf(Int(0))
+	default:
+		return nil
+	}
+}
+
+type shapeNode struct {
+	Key   Comparable `json:"key"`
+	Color Color      `json:"color"`
+	Size  int64      `json:"size"`
+	Left  *shapeNode `json:"left,omitempty"`
+	Right *shapeNode `json:"right,omitempty"`
+}
+
+// MarshalShapeJSON encodes tree structure as nested JSON objects with keys, colors and subtree sizes.
+// It is intended for debugging and visualization. Empty tree is encoded as null
+func MarshalShapeJSON(t RbTree) ([]byte, error) {
+	return json.Marshal(newShapeNode(t.Root()))
+}
+
+func newShapeNode(n *Node) *shapeNode {
+	if n.isNil() {
+		return nil
+	}
+	return &shapeNode{
+		Key:   n.key,
+		Color: n.Color(),
+		Size:  n.size,
+		Left:  newShapeNode(n.left),
+		Right: newShapeNode(n.right),
+	}
+}
+
+// MarshalJSON implements json.Marshaler interface for Color
+func (c Color) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// MarshalJSON implements json.Marshaler interface for Int
+func (x Int) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(x))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface for Int
+func (x *Int) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*int)(x))
+}
+
+// MarshalJSON implements json.Marshaler interface for Int64
+func (x Int64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int64(x))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface for Int64
+func (x *Int64) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*int64)(x))
+}
+
+// MarshalJSON implements json.Marshaler interface for String
+func (x *String) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(*x))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface for String
+func (x *String) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*string)(x))
+}
+
+// MarshalJSON implements json.Marshaler interface for Time
+func (x Time) MarshalJSON() ([]byte, error) {
+	return time.Time(x).MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface for Time
+func (x *Time) UnmarshalJSON(data []byte) error {
+	return (*time.Time)(x).UnmarshalJSON(data)
+}
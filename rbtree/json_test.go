@@ -0,0 +1,217 @@
+package rbtree
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type jsonPoint struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+func (p jsonPoint) Less(y Comparable) bool {
+	o := y.(jsonPoint)
+	return p.X < o.X || (p.X == o.X && p.Y < o.Y)
+}
+
+func (p jsonPoint) Equal(y Comparable) bool {
+	return p == y
+}
+
+func Test_MarshalJSON_SortedArray(t *testing.T) {
+	var tests = []struct {
+		name     string
+		tree     RbTree
+		expected string
+	}{
+		{"int", newIntTestTree(), "[2,3,4,6,7,9,13,15,17,18,20]"},
+		{"string", newStringTree([]string{"b", "a", "c"}), `["a","b","c"]`},
+		{"empty", New(), "[]"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+
+			// Act
+			data, err := json.Marshal(test.tree)
+
+			// Assert
+			ass.NoError(err)
+			ass.JSONEq(test.expected, string(data))
+		})
+	}
+}
+
+func Test_UnmarshalJSONInferredType_ContentAsExpected(t *testing.T) {
+	var tests = []struct {
+		name     string
+		data     string
+		expected []Comparable
+	}{
+		{"int", "[3, 1, 2, 2]", []Comparable{Int(1), Int(2), Int(2), Int(3)}},
+		{"negative int", "[-3, 1]", []Comparable{Int(-3), Int(1)}},
+		{"string", `["b", "a"]`, []Comparable{NewString("a"), NewString("b")}},
+		{"empty", "[]", []Comparable{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			tree := New()
+
+			// Act
+			err := json.Unmarshal([]byte(test.data), tree)
+
+			// Assert
+			ass.NoError(err)
+			result := make([]Comparable, 0)
+			NewAscend(tree).Foreach(func(c Comparable) {
+				result = append(result, c)
+			})
+			ass.Equal(test.expected, result)
+			ass.True(isValidRbTree(tree))
+		})
+	}
+}
+
+func Test_UnmarshalJSONRegisteredType_ContentAsExpected(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	RegisterKeyType("point", jsonPoint{})
+	tree, err := NewWithKeyType("point")
+	ass.NoError(err)
+	data := `[{"x":2,"y":1},{"x":1,"y":5},{"x":1,"y":2}]`
+
+	// Act
+	err = json.Unmarshal([]byte(data), tree)
+
+	// Assert
+	ass.NoError(err)
+	ass.Equal(jsonPoint{1, 2}, tree.Minimum().Key())
+	ass.Equal(jsonPoint{2, 1}, tree.Maximum().Key())
+	out, _ := json.Marshal(tree)
+	ass.JSONEq(`[{"x":1,"y":2},{"x":1,"y":5},{"x":2,"y":1}]`, string(out))
+}
+
+func Test_UnmarshalJSONBuiltinRegisteredTypes_ContentAsExpected(t *testing.T) {
+	var tests = []struct {
+		name     string
+		data     string
+		expected Comparable
+	}{
+		{"int", "[1]", Int(1)},
+		{"int64", "[1]", Int64(1)},
+		{"string", `["1"]`, NewString("1")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			tree, _ := NewWithKeyType(test.name)
+
+			// Act
+			err := json.Unmarshal([]byte(test.data), tree)
+
+			// Assert
+			ass.NoError(err)
+			ass.Equal(test.expected, tree.Root().Key())
+		})
+	}
+}
+
+func Test_UnmarshalJSONInvalid_Error(t *testing.T) {
+	int64Tree, _ := NewWithKeyType("int64")
+	stringTree, _ := NewWithKeyType("string")
+
+	var tests = []struct {
+		name string
+		tree RbTree
+		data string
+	}{
+		{"not array", New(), `{"a":1}`},
+		{"not inferable", New(), `[{"a":1}]`},
+		{"not inferable bool", New(), `[true]`},
+		{"mixed", New(), `[1, "a"]`},
+		{"float into int64", int64Tree, `[1.5]`},
+		{"null string", stringTree, `[null]`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+
+			// Act
+			err := json.Unmarshal([]byte(test.data), test.tree)
+
+			// Assert
+			ass.Error(err)
+			ass.Equal(int64(0), test.tree.Len())
+		})
+	}
+}
+
+func Test_NewWithKeyTypeUnknown_Error(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+
+	// Act
+	tree, err := NewWithKeyType("unknown")
+
+	// Assert
+	ass.Nil(tree)
+	ass.ErrorIs(err, ErrUnknownKeyType)
+}
+
+func Test_MarshalShapeJSON_StructureAsExpected(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTree([]int{2, 1, 3})
+
+	// Act
+	data, err := MarshalShapeJSON(tree)
+
+	// Assert
+	ass.NoError(err)
+	ass.JSONEq(`{"key":2,"color":"black","size":3,
+		"left":{"key":1,"color":"red","size":1},
+		"right":{"key":3,"color":"red","size":1}}`, string(data))
+}
+
+func Test_MarshalShapeJSONEmpty_Null(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+
+	// Act
+	data, err := MarshalShapeJSON(New())
+
+	// Assert
+	ass.NoError(err)
+	ass.Equal("null", string(data))
+}
+
+func Test_KeysJSON_RoundTrip(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	var i Int
+	var i64 Int64
+	var s String
+
+	// Act
+	ass.NoError(json.Unmarshal([]byte("5"), &i))
+	ass.NoError(json.Unmarshal([]byte("7"), &i64))
+	ass.NoError(json.Unmarshal([]byte(`"x"`), &s))
+	di, _ := json.Marshal(i)
+	di64, _ := json.Marshal(i64)
+	ds, _ := json.Marshal(&s)
+
+	// Assert
+	ass.Equal(Int(5), i)
+	ass.Equal(Int64(7), i64)
+	ass.Equal("x", s.String())
+	ass.Equal("5", string(di))
+	ass.Equal("7", string(di64))
+	ass.Equal(`"x"`, string(ds))
+}
@@ -0,0 +1,270 @@
+package rbtree
+
+import (
+	"bytes"
+	"math"
+	"time"
+)
+
+// This file contains built-in key types in addition to Int, Int64 and String
+
+// Int8 is the int8 type key that can be stored as Node's key
+type Int8 int8
+
+// Int16 is the int16 type key that can be stored as Node's key
+type Int16 int16
+
+// Int32 is the int32 type key that can be stored as Node's key
+type Int32 int32
+
+// Uint is the uint type key that can be stored as Node's key
+type Uint uint
+
+// Uint8 is the uint8 type key that can be stored as Node's key
+type Uint8 uint8
+
+// Uint16 is the uint16 type key that can be stored as Node's key
+type Uint16 uint16
+
+// Uint32 is the uint32 type key that can be stored as Node's key
+type Uint32 uint32
+
+// Uint64 is the uint64 type key that can be stored as Node's key
+type Uint64 uint64
+
+// Float32 is the float32 type key that can be stored as Node's key.
+// NaN is less than any other value (including -Inf) and all NaNs are equal to each other
+type Float32 float32
+
+// Float64 is the float64 type key that can be stored as Node's key.
+// NaN is less than any other value (including -Inf) and all NaNs are equal to each other
+type Float64 float64
+
+// Time is the time.Time type key that can be stored as Node's key.
+// Keys are compared as time instants so the same instant in different locations is equal
+type Time time.Time
+
+// Bytes is the byte slice type key that can be stored as Node's key. Keys are compared
+// lexicographically using bytes.Compare. Slice must not be modified while the key is in a tree
+type Bytes []byte
+
+// Bool is the bool type key that can be stored as Node's key. False is less than true
+type Bool bool
+
+// Less define Comparable interface member for Int8
+func (x Int8) Less(y Comparable) bool {
+	return x < y.(Int8)
+}
+
+// Equal define Comparable interface member for Int8
+func (x Int8) Equal(y Comparable) bool {
+	return x == y
+}
+
+// Less define Comparable interface member for Int16
+func (x Int16) Less(y Comparable) bool {
+	return x < y.(Int16)
+}
+
+// Equal define Comparable interface member for Int16
+func (x Int16) Equal(y Comparable) bool {
+	return x == y
+}
+
+// Less define Comparable interface member for Int32
+func (x Int32) Less(y Comparable) bool {
+	return x < y.(Int32)
+}
+
+// Equal define Comparable interface member for Int32
+func (x Int32) Equal(y Comparable) bool {
+	return x == y
+}
+
+// Less define Comparable interface member for Uint
+func (x Uint) Less(y Comparable) bool {
+	return x < y.(Uint)
+}
+
+// Equal define Comparable interface member for Uint
+func (x Uint) Equal(y Comparable) bool {
+	return x == y
+}
+
+// Less define Comparable interface member for Uint8
+func (x Uint8) Less(y Comparable) bool {
+	return x < y.(Uint8)
+}
+
+// Equal define Comparable interface member for Uint8
+func (x Uint8) Equal(y Comparable) bool {
+	return x == y
+}
+
+// Less define Comparable interface member for Uint16
+func (x Uint16) Less(y Comparable) bool {
+	return x < y.(Uint16)
+}
+
+// Equal define Comparable interface member for Uint16
+func (x Uint16) Equal(y Comparable) bool {
+	return x == y
+}
+
+// Less define Comparable interface member for Uint32
+func (x Uint32) Less(y Comparable) bool {
+	return x < y.(Uint32)
+}
+
+// Equal define Comparable interface member for Uint32
+func (x Uint32) Equal(y Comparable) bool {
+	return x == y
+}
+
+// Less define Comparable interface member for Uint64
+func (x Uint64) Less(y Comparable) bool {
+	return x < y.(Uint64)
+}
+
+// Equal define Comparable interface member for Uint64
+func (x Uint64) Equal(y Comparable) bool {
+	return x == y
+}
+
+// Less define Comparable interface member for Float32
+func (x Float32) Less(y Comparable) bool {
+	return floatLess(float64(x), float64(y.(Float32)))
+}
+
+// Equal define Comparable interface member for Float32
+func (x Float32) Equal(y Comparable) bool {
+	v, ok := y.(Float32)
+	return ok && floatEqual(float64(x), float64(v))
+}
+
+// Less define Comparable interface member for Float64
+func (x Float64) Less(y Comparable) bool {
+	return floatLess(float64(x), float64(y.(Float64)))
+}
+
+// Equal define Comparable interface member for Float64
+func (x Float64) Equal(y Comparable) bool {
+	v, ok := y.(Float64)
+	return ok && floatEqual(float64(x), float64(v))
+}
+
+// Less define Comparable interface member for Time
+func (x Time) Less(y Comparable) bool {
+	return time.Time(x).Before(time.Time(y.(Time)))
+}
+
+// Equal define Comparable interface member for Time
+func (x Time) Equal(y Comparable) bool {
+	v, ok := y.(Time)
+	return ok && time.Time(x).Equal(time.Time(v))
+}
+
+func (x Time) String() string {
+	return time.Time(x).String()
+}
+
+// Less define Comparable interface member for Bytes
+func (x Bytes) Less(y Comparable) bool {
+	return bytes.Compare(x, y.(Bytes)) < 0
+}
+
+// Equal define Comparable interface member for Bytes
+func (x Bytes) Equal(y Comparable) bool {
+	v, ok := y.(Bytes)
+	return ok && bytes.Equal(x, v)
+}
+
+// HasPrefix gets whether the byte slice starts with the prefix specified
+func (x Bytes) HasPrefix(prefix Comparable) bool {
+	return bytes.HasPrefix(x, prefix.(Bytes))
+}
+
+// Less define Comparable interface member for Bool
+func (x Bool) Less(y Comparable) bool {
+	return !bool(x) && bool(y.(Bool))
+}
+
+// Equal define Comparable interface member for Bool
+func (x Bool) Equal(y Comparable) bool {
+	return x == y
+}
+
+// floatLess orders NaN before any other value
+func floatLess(x float64, y float64) bool {
+	return x < y || (math.IsNaN(x) && !math.IsNaN(y))
+}
+
+// floatEqual treats all NaNs as equal
+func floatEqual(x float64, y float64) bool {
+	return x == y || (math.IsNaN(x) && math.IsNaN(y))
+}
+
+// GetInt8 gets int8 value from Comparable
+func GetInt8(c Comparable) int8 {
+	return int8(c.(Int8))
+}
+
+// GetInt16 gets int16 value from Comparable
+func GetInt16(c Comparable) int16 {
+	return int16(c.(Int16))
+}
+
+// GetInt32 gets int32 value from Comparable
+func GetInt32(c Comparable) int32 {
+	return int32(c.(Int32))
+}
+
+// GetUint gets uint value from Comparable
+func GetUint(c Comparable) uint {
+	return uint(c.(Uint))
+}
+
+// GetUint8 gets uint8 value from Comparable
+func GetUint8(c Comparable) uint8 {
+	return uint8(c.(Uint8))
+}
+
+// GetUint16 gets uint16 value from Comparable
+func GetUint16(c Comparable) uint16 {
+	return uint16(c.(Uint16))
+}
+
+// GetUint32 gets uint32 value from Comparable
+func GetUint32(c Comparable) uint32 {
+	return uint32(c.(Uint32))
+}
+
+// GetUint64 gets uint64 value from Comparable
+func GetUint64(c Comparable) uint64 {
+	return uint64(c.(Uint64))
+}
+
+// GetFloat32 gets float32 value from Comparable
+func GetFloat32(c Comparable) float32 {
+	return float32(c.(Float32))
+}
+
+// GetFloat64 gets float64 value from Comparable
+func GetFloat64(c Comparable) float64 {
+	return float64(c.(Float64))
+}
+
+// GetTime gets time.Time value from Comparable
+func GetTime(c Comparable) time.Time {
+	return time.Time(c.(Time))
+}
+
+// GetBytes gets byte slice value from Comparable
+func GetBytes(c Comparable) []byte {
+	return c.(Bytes)
+}
+
+// GetBool gets bool value from Comparable
+func GetBool(c Comparable) bool {
+	return bool(c.(Bool))
+}
@@ -0,0 +1,172 @@
+package rbtree
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+)
+
+func Test_KeyTypes_LessAndEqual(t *testing.T) {
+	now := time.Now()
+	var tests = []struct {
+		name    string
+		smaller Comparable
+		greater Comparable
+	}{
+		{"Int8", Int8(-3), Int8(5)},
+		{"Int16", Int16(-300), Int16(500)},
+		{"Int32", Int32(-70000), Int32(70000)},
+		{"Uint", Uint(1), Uint(math.MaxUint)},
+		{"Uint8", Uint8(1), Uint8(255)},
+		{"Uint16", Uint16(1), Uint16(65535)},
+		{"Uint32", Uint32(1), Uint32(math.MaxUint32)},
+		{"Uint64", Uint64(1), Uint64(math.MaxUint64)},
+		{"Float32", Float32(-1.5), Float32(2.5)},
+		{"Float64", Float64(-1.5), Float64(2.5)},
+		{"Float64 NaN", Float64(math.NaN()), Float64(math.Inf(-1))},
+		{"Float32 NaN", Float32(math.NaN()), Float32(math.Inf(-1))},
+		{"Time", Time(now), Time(now.Add(time.Nanosecond))},
+		{"Bytes", Bytes("abc"), Bytes("abd")},
+		{"Bytes prefix", Bytes("ab"), Bytes("abc")},
+		{"Bytes empty", Bytes{}, Bytes{0}},
+		{"Bool", Bool(false), Bool(true)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+
+			// Act
+
+			// Assert
+			ass.True(test.smaller.Less(test.greater))
+			ass.False(test.greater.Less(test.smaller))
+			ass.False(test.smaller.Less(test.smaller))
+			ass.True(test.smaller.Equal(test.smaller))
+			ass.True(test.greater.Equal(test.greater))
+			ass.False(test.smaller.Equal(test.greater))
+		})
+	}
+}
+
+func Test_Float64Tree_NaNOrderedFirstAndSearchable(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	for _, v := range []float64{3, math.NaN(), math.Inf(1), -1, math.NaN(), math.Inf(-1)} {
+		tree.Insert(Float64(v))
+	}
+
+	// Act
+	first, last, count := tree.EqualRange(Float64(math.NaN()))
+
+	// Assert
+	ass.Equal(int64(2), count)
+	ass.Equal(int64(1), first.Rank())
+	ass.Equal(int64(2), last.Rank())
+	ass.True(math.IsInf(GetFloat64(tree.Maximum().Key()), 1))
+	ass.True(isValidRbTree(tree))
+}
+
+func Test_TimeKey_SameInstantDifferentLocation_Equal(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	utc := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	local := utc.In(time.FixedZone("UTC+3", 3*60*60))
+	tree := New()
+	tree.Insert(Time(utc))
+
+	// Act
+	found, ok := tree.Search(Time(local))
+
+	// Assert
+	ass.True(ok)
+	ass.True(utc.Equal(GetTime(found)))
+}
+
+func Test_Getters(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	now := time.Now()
+
+	// Act
+
+	// Assert
+	ass.Equal(int8(1), GetInt8(Int8(1)))
+	ass.Equal(int16(2), GetInt16(Int16(2)))
+	ass.Equal(int32(3), GetInt32(Int32(3)))
+	ass.Equal(uint(4), GetUint(Uint(4)))
+	ass.Equal(uint8(5), GetUint8(Uint8(5)))
+	ass.Equal(uint16(6), GetUint16(Uint16(6)))
+	ass.Equal(uint32(7), GetUint32(Uint32(7)))
+	ass.Equal(uint64(8), GetUint64(Uint64(8)))
+	ass.Equal(float32(9.5), GetFloat32(Float32(9.5)))
+	ass.Equal(10.5, GetFloat64(Float64(10.5)))
+	ass.Equal(now, GetTime(Time(now)))
+	ass.Equal([]byte("11"), GetBytes(Bytes("11")))
+	ass.True(GetBool(Bool(true)))
+}
+
+func Test_InterpolatedQuantile_FloatAndUnsignedKeys(t *testing.T) {
+	var tests = []struct {
+		name string
+		keys []Comparable
+	}{
+		{"Float64", []Comparable{Float64(1), Float64(2), Float64(3), Float64(4)}},
+		{"Float32", []Comparable{Float32(1), Float32(2), Float32(3), Float32(4)}},
+		{"Uint64", []Comparable{Uint64(1), Uint64(2), Uint64(3), Uint64(4)}},
+		{"Int16", []Comparable{Int16(1), Int16(2), Int16(3), Int16(4)}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			tree := New()
+			for _, k := range test.keys {
+				tree.Insert(k)
+			}
+
+			// Act
+			q, err := tree.InterpolatedQuantile(0.5)
+
+			// Assert
+			ass.NoError(err)
+			ass.InDelta(2.5, q, 1e-9)
+		})
+	}
+}
+
+func Test_JSON_NewKeyTypes_RoundTrip(t *testing.T) {
+	var tests = []struct {
+		name string
+		keys []Comparable
+	}{
+		{"uint64", []Comparable{Uint64(1), Uint64(math.MaxUint64)}},
+		{"float64", []Comparable{Float64(-1.5), Float64(2)}},
+		{"time", []Comparable{Time(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), Time(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))}},
+		{"bytes", []Comparable{Bytes("a"), Bytes("b")}},
+		{"bool", []Comparable{Bool(false), Bool(true)}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			tree, _ := NewWithKeyType(test.name)
+			for _, k := range test.keys {
+				tree.Insert(k)
+			}
+			restored, _ := NewWithKeyType(test.name)
+
+			// Act
+			data, err := json.Marshal(tree)
+			ass.NoError(err)
+			err = json.Unmarshal(data, restored)
+
+			// Assert
+			ass.NoError(err)
+			ass.True(Equal(tree, restored))
+		})
+	}
+}
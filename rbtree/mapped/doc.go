@@ -0,0 +1,13 @@
+// Package mapped provides read-only on-disk Red-black tree format. Writer serializes tree keys
+// in ascending order together with Eytzinger search layout and the reader memory-maps the file
+// and answers search, order statistic and range queries directly from the mapping
+// without building heap nodes.
+//
+// File layout (all integers are little endian):
+//
+//	header   magic "RBTM", version uint16, reserved uint16, keys count uint64,
+//	         body length uint64, CRC-32C of the body uint32, reserved uint32
+//	layout   count uint64 ranks of keys in Eytzinger order
+//	offsets  count+1 uint64 offsets of encoded keys in ascending order
+//	keys     encoded keys
+package mapped
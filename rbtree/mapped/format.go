@@ -0,0 +1,26 @@
+package mapped
+
+import (
+	"errors"
+	"hash/crc32"
+)
+
+// This file contains on-disk format definitions
+
+// ErrInvalidFile is returned when the file isn't a valid mapped tree
+var ErrInvalidFile = errors.New("invalid mapped tree file")
+
+// ErrChecksum is returned when the file content doesn't match its checksum
+var ErrChecksum = errors.New("mapped tree checksum mismatch")
+
+// Version is the current file format version
+const Version = 1
+
+const headerSize = 32
+
+// word is the size of layout and offsets entries
+const word = 8
+
+var magic = []byte{'R', 'B', 'T', 'M'}
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
@@ -0,0 +1,82 @@
+package mapped
+
+import "github.com/aegoroff/godatastruct/rbtree"
+
+// This file contains mapped tree iteration methods implementations
+
+type ascend struct {
+	tree *Tree
+	curr int64
+	next int64
+	to   int64
+}
+
+type descend struct{ ascend }
+
+type enumerable struct{ it rbtree.Iterator }
+
+// NewAscend creates Enumerable that walks all keys in ascending order
+func (t *Tree) NewAscend() rbtree.Enumerable {
+	return t.newAscend(0, t.count-1)
+}
+
+// NewAscendRange creates Enumerable that walks keys in ascending order within the range [from, to].
+// Both ends not necessary present in the tree
+func (t *Tree) NewAscendRange(from, to rbtree.Comparable) rbtree.Enumerable {
+	if from == nil || to == nil {
+		return t.newAscend(0, -1)
+	}
+	return t.newAscend(t.lowerBound(from), t.upperBound(to)-1)
+}
+
+// NewDescend creates Enumerable that walks all keys in descending order
+func (t *Tree) NewDescend() rbtree.Enumerable {
+	return t.newDescend(t.count-1, 0)
+}
+
+// NewDescendRange creates Enumerable that walks keys in descending order within the range [to, from].
+// Both ends not necessary present in the tree
+func (t *Tree) NewDescendRange(from, to rbtree.Comparable) rbtree.Enumerable {
+	if from == nil || to == nil {
+		return t.newDescend(-1, 0)
+	}
+	return t.newDescend(t.upperBound(from)-1, t.lowerBound(to))
+}
+
+func (i *ascend) Next() bool {
+	result := i.next <= i.to
+	if result {
+		i.curr = i.next
+		i.next++
+	}
+	return result
+}
+
+func (i *descend) Next() bool {
+	result := i.next >= i.to && i.next >= 0
+	if result {
+		i.curr = i.next
+		i.next--
+	}
+	return result
+}
+
+func (i *ascend) Current() rbtree.Comparable { return i.tree.key(i.curr) }
+
+// Foreach does tree iteration and calls the callback for
+// every key within the range.
+func (e *enumerable) Foreach(callback rbtree.NodeAction) {
+	for e.it.Next() {
+		callback(e.it.Current())
+	}
+}
+
+func (e *enumerable) Iterator() rbtree.Iterator { return e.it }
+
+func (t *Tree) newAscend(from, to int64) rbtree.Enumerable {
+	return &enumerable{it: &ascend{tree: t, next: from, to: to}}
+}
+
+func (t *Tree) newDescend(from, to int64) rbtree.Enumerable {
+	return &enumerable{it: &descend{ascend{tree: t, next: from, to: to}}}
+}
@@ -0,0 +1,57 @@
+package mapped
+
+import (
+	"github.com/aegoroff/godatastruct/rbtree"
+	"math/rand"
+	"path/filepath"
+	"testing"
+)
+
+const treeSizeSearch = 50000
+const searches = 64
+
+func Benchmark_MappedTree_Search(b *testing.B) {
+	// Arrange
+	tree := rbtree.New()
+	for _, n := range rand.Perm(treeSizeSearch) {
+		tree.Insert(rbtree.Int(n * 2))
+	}
+	path := filepath.Join(b.TempDir(), "tree.rbtm")
+	if err := WriteFile(path, tree, rbtree.IntCodec); err != nil {
+		b.Fatal(err)
+	}
+	mapped, err := Open(path, rbtree.IntCodec)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer mapped.Close()
+	off := rand.Intn(treeSizeSearch / 2)
+
+	// Act
+	for i := 0; i < b.N; i++ {
+		for j := range searches {
+			mapped.Search(rbtree.Int((j + off) * 2))
+			mapped.Search(rbtree.Int((j+off)*2 + 1))
+		}
+	}
+	b.ReportAllocs()
+}
+
+func Benchmark_MappedTree_Open(b *testing.B) {
+	// Arrange
+	tree := rbtree.New()
+	for _, n := range rand.Perm(treeSizeSearch) {
+		tree.Insert(rbtree.Int(n))
+	}
+	path := filepath.Join(b.TempDir(), "tree.rbtm")
+	if err := WriteFile(path, tree, rbtree.IntCodec); err != nil {
+		b.Fatal(err)
+	}
+
+	// Act
+	for i := 0; i < b.N; i++ {
+		mapped, _ := Open(path, rbtree.IntCodec)
+		_ = mapped.Close()
+	}
+	b.ReportAllocs()
+}
@@ -0,0 +1,340 @@
+package mapped
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"github.com/aegoroff/godatastruct/rbtree"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func Test_WriteFileOpen_SameKeysAsTree(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTestTree()
+	path := filepath.Join(t.TempDir(), "tree.rbtm")
+
+	// Act
+	err := WriteFile(path, tree, rbtree.IntCodec)
+	mapped, openErr := Open(path, rbtree.IntCodec)
+
+	// Assert
+	ass.NoError(err)
+	ass.NoError(openErr)
+	defer mapped.Close()
+	ass.Equal(tree.Len(), mapped.Len())
+	ass.Equal(collect(rbtree.NewAscend(tree)), collect(mapped.NewAscend()))
+	ass.Equal(collect(rbtree.NewDescend(tree)), collect(mapped.NewDescend()))
+	min, _ := mapped.Minimum()
+	max, _ := mapped.Maximum()
+	ass.Equal(rbtree.Int(2), min)
+	ass.Equal(rbtree.Int(20), max)
+}
+
+func Test_StringKeys_Search(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := rbtree.New()
+	for _, s := range []string{"pear", "apple", "fig", "plum", "apple"} {
+		tree.Insert(rbtree.NewString(s))
+	}
+	mapped := newMapped(t, tree, rbtree.StringCodec)
+
+	// Act
+	found, ok := mapped.Search(rbtree.NewString("fig"))
+	_, notFound := mapped.Search(rbtree.NewString("kiwi"))
+	rank, _ := mapped.Rank(rbtree.NewString("pear"))
+	floor, _ := mapped.Floor(rbtree.NewString("kiwi"))
+
+	// Assert
+	ass.True(ok)
+	ass.Equal("fig", found.(*rbtree.String).String())
+	ass.False(notFound)
+	ass.Equal(int64(4), rank)
+	ass.Equal("fig", floor.(*rbtree.String).String())
+}
+
+func Test_FloorCeiling(t *testing.T) {
+	var tests = []struct {
+		x       int
+		floor   rbtree.Comparable
+		ceiling rbtree.Comparable
+	}{
+		{1, nil, rbtree.Int(2)},
+		{2, rbtree.Int(2), rbtree.Int(2)},
+		{5, rbtree.Int(4), rbtree.Int(6)},
+		{14, rbtree.Int(13), rbtree.Int(15)},
+		{20, rbtree.Int(20), rbtree.Int(20)},
+		{21, rbtree.Int(20), nil},
+	}
+	for _, test := range tests {
+		// Arrange
+		ass := assert.New(t)
+		mapped := newMapped(t, newIntTestTree(), rbtree.IntCodec)
+
+		// Act
+		floor, floorOk := mapped.Floor(rbtree.Int(test.x))
+		ceiling, ceilingOk := mapped.Ceiling(rbtree.Int(test.x))
+
+		// Assert
+		ass.Equal(test.floor != nil, floorOk, "x = %d", test.x)
+		ass.Equal(test.floor, floor, "x = %d", test.x)
+		ass.Equal(test.ceiling != nil, ceilingOk, "x = %d", test.x)
+		ass.Equal(test.ceiling, ceiling, "x = %d", test.x)
+	}
+}
+
+func Test_Ranges(t *testing.T) {
+	var tests = []struct {
+		from       int
+		to         int
+		ascending  []int
+		descending []int
+	}{
+		{6, 15, []int{6, 7, 9, 13, 15}, nil},
+		{5, 14, []int{6, 7, 9, 13}, nil},
+		{15, 6, nil, []int{15, 13, 9, 7, 6}},
+		{14, 5, nil, []int{13, 9, 7, 6}},
+		{-10, 3, []int{2, 3}, nil},
+		{100, 19, nil, []int{20}},
+		{21, 100, nil, nil},
+	}
+	for _, test := range tests {
+		// Arrange
+		ass := assert.New(t)
+		mapped := newMapped(t, newIntTestTree(), rbtree.IntCodec)
+
+		// Act
+		ascending := collect(mapped.NewAscendRange(rbtree.Int(test.from), rbtree.Int(test.to)))
+		descending := collect(mapped.NewDescendRange(rbtree.Int(test.from), rbtree.Int(test.to)))
+
+		// Assert
+		ass.Equal(test.ascending, ascending, "[%d, %d]", test.from, test.to)
+		ass.Equal(test.descending, descending, "[%d, %d]", test.from, test.to)
+	}
+}
+
+func Test_EmptyTree_NothingFound(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	mapped := newMapped(t, rbtree.New(), rbtree.IntCodec)
+
+	// Act
+	_, found := mapped.Search(rbtree.Int(1))
+	_, floor := mapped.Floor(rbtree.Int(1))
+	_, ceiling := mapped.Ceiling(rbtree.Int(1))
+	_, min := mapped.Minimum()
+	_, rank := mapped.Rank(rbtree.Int(1))
+
+	// Assert
+	ass.Equal(int64(0), mapped.Len())
+	ass.False(found)
+	ass.False(floor)
+	ass.False(ceiling)
+	ass.False(min)
+	ass.False(rank)
+	ass.Empty(collect(mapped.NewAscend()))
+	ass.Empty(collect(mapped.NewDescendRange(rbtree.Int(10), rbtree.Int(1))))
+}
+
+func Test_RandomKeysWithDuplicates_EqualToSortedSlice(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	r := rand.New(rand.NewSource(1000))
+	tree := rbtree.New()
+	keys := make([]int, 1000)
+	for i := range keys {
+		keys[i] = r.Intn(300) * 2
+		tree.Insert(rbtree.Int(keys[i]))
+	}
+	sort.Ints(keys)
+
+	// Act
+	mapped := newMapped(t, tree, rbtree.IntCodec)
+
+	// Assert
+	for i, k := range keys {
+		c, ok := mapped.OrderStatisticSelect(int64(i + 1))
+		ass.True(ok)
+		ass.Equal(rbtree.Int(k), c)
+	}
+	for x := -1; x < 602; x++ {
+		ix := sort.SearchInts(keys, x)
+		found := ix < len(keys) && keys[ix] == x
+		rank, ok := mapped.Rank(rbtree.Int(x))
+		ceiling, ceilingOk := mapped.Ceiling(rbtree.Int(x))
+		ass.Equal(found, ok, "x = %d", x)
+		if found {
+			ass.Equal(int64(ix+1), rank, "x = %d", x)
+		}
+		ass.Equal(ix < len(keys), ceilingOk, "x = %d", x)
+		if ceilingOk {
+			ass.Equal(rbtree.Int(keys[ix]), ceiling, "x = %d", x)
+		}
+	}
+}
+
+func Test_FromBytes_CorruptedData_Error(t *testing.T) {
+	var tests = []struct {
+		name     string
+		corrupt  func([]byte) []byte
+		expected error
+	}{
+		{"magic", func(b []byte) []byte { b[0] = 'X'; return b }, ErrInvalidFile},
+		{"version", func(b []byte) []byte { binary.LittleEndian.PutUint16(b[4:], 2); return b }, ErrInvalidFile},
+		{"truncated", func(b []byte) []byte { return b[:len(b)-1] }, ErrInvalidFile},
+		{"short", func(b []byte) []byte { return b[:10] }, ErrInvalidFile},
+		{"checksum", func(b []byte) []byte { b[len(b)-1]++; return b }, ErrChecksum},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			buf := bytes.Buffer{}
+			_ = Write(&buf, newIntTestTree(), rbtree.IntCodec)
+
+			// Act
+			_, err := FromBytes(test.corrupt(buf.Bytes()), rbtree.IntCodec)
+
+			// Assert
+			ass.True(errors.Is(err, test.expected), "%v", err)
+		})
+	}
+}
+
+func Test_FromBytes_NilCodec_Error(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	buf := bytes.Buffer{}
+	_ = Write(&buf, newIntTestTree(), rbtree.IntCodec)
+
+	// Act
+	_, err := FromBytes(buf.Bytes(), nil)
+
+	// Assert
+	ass.ErrorIs(err, rbtree.ErrNoKeyCodec)
+}
+
+func Test_FromBytes_UndecodableKeys_Error(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := rbtree.New()
+	for _, s := range []string{"apple", "fig", "pear"} {
+		tree.Insert(rbtree.NewString(s))
+	}
+	buf := bytes.Buffer{}
+	_ = Write(&buf, tree, rbtree.StringCodec)
+
+	// Act
+	_, err := FromBytes(buf.Bytes(), rbtree.IntCodec)
+
+	// Assert
+	ass.ErrorIs(err, ErrInvalidFile)
+	ass.ErrorIs(err, rbtree.ErrInvalidKey)
+}
+
+func Test_Open_UndecodableKeys_Error(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := rbtree.New()
+	tree.Insert(rbtree.NewString("apple"))
+	path := filepath.Join(t.TempDir(), "tree.rbtm")
+	_ = WriteFile(path, tree, rbtree.StringCodec)
+
+	// Act
+	_, err := Open(path, rbtree.IntCodec)
+
+	// Assert
+	ass.ErrorIs(err, ErrInvalidFile)
+	ass.ErrorIs(err, rbtree.ErrInvalidKey)
+}
+
+func Test_FromBytes_UnsortedKeys_Error(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	buf := bytes.Buffer{}
+	_ = Write(&buf, newIntTestTree(), rbtree.IntCodec)
+
+	// Act
+	_, err := FromBytes(buf.Bytes(), negatedCodec{})
+
+	// Assert
+	ass.ErrorIs(err, ErrInvalidFile)
+}
+
+func Test_Write_KeyCodecMismatch_Error(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	buf := bytes.Buffer{}
+
+	// Act
+	err := Write(&buf, newIntTestTree(), rbtree.StringCodec)
+
+	// Assert
+	ass.ErrorIs(err, rbtree.ErrInvalidKey)
+}
+
+func Test_Open_NotExistingOrTooSmall_Error(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	dir := t.TempDir()
+	small := filepath.Join(dir, "small")
+	_ = os.WriteFile(small, []byte("RBTM"), 0o600)
+
+	// Act
+	_, notExistErr := Open(filepath.Join(dir, "missing"), rbtree.IntCodec)
+	_, smallErr := Open(small, rbtree.IntCodec)
+
+	// Assert
+	ass.ErrorIs(notExistErr, os.ErrNotExist)
+	ass.ErrorIs(smallErr, ErrInvalidFile)
+}
+
+// negatedCodec decodes Int keys written by rbtree.IntCodec negated so their order is reversed
+type negatedCodec struct{}
+
+func (negatedCodec) EncodeKey(dst []byte, c rbtree.Comparable) ([]byte, error) {
+	return rbtree.IntCodec.EncodeKey(dst, -c.(rbtree.Int))
+}
+
+func (negatedCodec) DecodeKey(data []byte) (rbtree.Comparable, error) {
+	c, err := rbtree.IntCodec.DecodeKey(data)
+	if err != nil {
+		return nil, err
+	}
+	return -c.(rbtree.Int), nil
+}
+
+func newMapped(t *testing.T, tree rbtree.RbTree, codec rbtree.KeyCodec) *Tree {
+	path := filepath.Join(t.TempDir(), "tree.rbtm")
+	if err := WriteFile(path, tree, codec); err != nil {
+		t.Fatal(err)
+	}
+	mapped, err := Open(path, codec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = mapped.Close() })
+	return mapped
+}
+
+func newIntTestTree() rbtree.RbTree {
+	tree := rbtree.New()
+	for _, n := range []int{6, 18, 3, 15, 7, 2, 4, 13, 9, 17, 20} {
+		tree.Insert(rbtree.Int(n))
+	}
+	return tree
+}
+
+func collect(e rbtree.Enumerable) []int {
+	var result []int
+	e.Foreach(func(c rbtree.Comparable) {
+		result = append(result, rbtree.GetInt(c))
+	})
+	return result
+}
@@ -0,0 +1,19 @@
+//go:build !unix
+
+package mapped
+
+import (
+	"io"
+	"os"
+)
+
+// This file contains fallback for systems without memory mapping support
+// that reads the whole file into memory
+
+func mmap(f *os.File, size int64) ([]byte, func() error, error) {
+	data := make([]byte, size)
+	if _, err := io.ReadFull(f, data); err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return nil }, nil
+}
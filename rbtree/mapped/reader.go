@@ -0,0 +1,264 @@
+package mapped
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/aegoroff/godatastruct/rbtree"
+	"hash/crc32"
+	"os"
+)
+
+// This file contains mapped tree reader implementation
+
+// Tree is read-only tree that answers queries directly from the file content.
+// Keys are decoded on demand so only the keys touched by a query are allocated
+type Tree struct {
+	data    []byte
+	layout  []byte
+	offsets []byte
+	keys    []byte
+	count   int64
+	codec   rbtree.KeyCodec
+	unmap   func() error
+}
+
+// Open memory-maps the file specified and validates its header, checksum, key offsets and search layout.
+// It also decodes every key once using codec specified and checks that keys are sorted so that
+// a codec mismatch or bad keys are reported here rather than by queries. This is O(n) but decoded keys
+// aren't retained: queries decode keys on demand. Tree must be closed when no longer needed
+func Open(path string, codec rbtree.KeyCodec) (*Tree, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if fi.Size() < headerSize {
+		return nil, fmt.Errorf("%w: file too small", ErrInvalidFile)
+	}
+
+	data, unmap, err := mmap(f, fi.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := newTree(data, codec)
+	if err != nil {
+		_ = unmap()
+		return nil, err
+	}
+	t.unmap = unmap
+	return t, nil
+}
+
+// FromBytes creates Tree over data written by Write without copying it.
+// It validates data like Open does
+func FromBytes(data []byte, codec rbtree.KeyCodec) (*Tree, error) {
+	return newTree(data, codec)
+}
+
+// Close unmaps the file. Tree must not be used after closing
+func (t *Tree) Close() error {
+	unmap := t.unmap
+	t.unmap = nil
+	t.data, t.layout, t.offsets, t.keys, t.count = nil, nil, nil, nil, 0
+	if unmap == nil {
+		return nil
+	}
+	return unmap()
+}
+
+// Len returns the number of keys in the tree.
+func (t *Tree) Len() int64 {
+	return t.count
+}
+
+// Search searches value specified within the tree
+func (t *Tree) Search(value rbtree.Comparable) (rbtree.Comparable, bool) {
+	if value == nil {
+		return nil, false
+	}
+	r := t.lowerBound(value)
+	if r == t.count {
+		return nil, false
+	}
+	c := t.key(r)
+	if !c.Equal(value) {
+		return nil, false
+	}
+	return c, true
+}
+
+// Floor searches the greatest key lesser than or equal to value specified.
+// It returns false if all keys are greater than value
+func (t *Tree) Floor(value rbtree.Comparable) (rbtree.Comparable, bool) {
+	if value == nil {
+		return nil, false
+	}
+	return t.OrderStatisticSelect(t.upperBound(value))
+}
+
+// Ceiling searches the smallest key larger than or equal to value specified.
+// It returns false if all keys are less than value
+func (t *Tree) Ceiling(value rbtree.Comparable) (rbtree.Comparable, bool) {
+	if value == nil {
+		return nil, false
+	}
+	return t.OrderStatisticSelect(t.lowerBound(value) + 1)
+}
+
+// Minimum gets tree's min key
+func (t *Tree) Minimum() (rbtree.Comparable, bool) {
+	return t.OrderStatisticSelect(1)
+}
+
+// Maximum gets tree's max key
+func (t *Tree) Maximum() (rbtree.Comparable, bool) {
+	return t.OrderStatisticSelect(t.count)
+}
+
+// OrderStatisticSelect gets i key from the tree in O(1)
+// IMPORTANT: numeration starts from 1 not from 0
+func (t *Tree) OrderStatisticSelect(i int64) (rbtree.Comparable, bool) {
+	if i < 1 || i > t.count {
+		return nil, false
+	}
+	return t.key(i - 1), true
+}
+
+// Rank gets the rank (starting from 1) of the first key equal to value specified
+func (t *Tree) Rank(value rbtree.Comparable) (int64, bool) {
+	if value == nil {
+		return 0, false
+	}
+	r := t.lowerBound(value)
+	if r == t.count || !t.key(r).Equal(value) {
+		return 0, false
+	}
+	return r + 1, true
+}
+
+// key decodes key of zero based rank specified. All keys were successfully decoded by validate
+// so decoding cannot fail unless the data was modified after opening
+func (t *Tree) key(rank int64) rbtree.Comparable {
+	c, err := t.codec.DecodeKey(t.keyBytes(rank))
+	if err != nil {
+		panic(fmt.Errorf("%w: key %d modified after opening: %w", ErrInvalidFile, rank, err))
+	}
+	return c
+}
+
+func (t *Tree) keyBytes(rank int64) []byte {
+	from := binary.LittleEndian.Uint64(t.offsets[rank*word:])
+	to := binary.LittleEndian.Uint64(t.offsets[(rank+1)*word:])
+	return t.keys[from:to]
+}
+
+// rankAt gets zero based rank of the key at Eytzinger position k (starting from 1)
+func (t *Tree) rankAt(k int64) int64 {
+	return int64(binary.LittleEndian.Uint64(t.layout[(k-1)*word:]))
+}
+
+// lowerBound gets zero based rank of the first key that isn't less than value
+// or the number of keys if all keys are less than value
+func (t *Tree) lowerBound(value rbtree.Comparable) int64 {
+	result := t.count
+	k := int64(1)
+	for k <= t.count {
+		r := t.rankAt(k)
+		if t.key(r).Less(value) {
+			k = 2*k + 1
+		} else {
+			result = r
+			k = 2 * k
+		}
+	}
+	return result
+}
+
+// upperBound gets zero based rank of the first key that is greater than value
+// or the number of keys if all keys are less than or equal to value
+func (t *Tree) upperBound(value rbtree.Comparable) int64 {
+	result := t.count
+	k := int64(1)
+	for k <= t.count {
+		r := t.rankAt(k)
+		if value.Less(t.key(r)) {
+			result = r
+			k = 2 * k
+		} else {
+			k = 2*k + 1
+		}
+	}
+	return result
+}
+
+func newTree(data []byte, codec rbtree.KeyCodec) (*Tree, error) {
+	if codec == nil {
+		return nil, rbtree.ErrNoKeyCodec
+	}
+	if len(data) < headerSize || string(data[:len(magic)]) != string(magic) {
+		return nil, fmt.Errorf("%w: bad header", ErrInvalidFile)
+	}
+	if v := binary.LittleEndian.Uint16(data[4:]); v != Version {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidFile, v)
+	}
+
+	count := binary.LittleEndian.Uint64(data[8:])
+	length := binary.LittleEndian.Uint64(data[16:])
+	body := data[headerSize:]
+	if length != uint64(len(body)) {
+		return nil, fmt.Errorf("%w: body length mismatch", ErrInvalidFile)
+	}
+	if crc32.Checksum(body, castagnoli) != binary.LittleEndian.Uint32(data[24:]) {
+		return nil, ErrChecksum
+	}
+	if length < word || count > (length-word)/(2*word) {
+		return nil, fmt.Errorf("%w: bad keys count", ErrInvalidFile)
+	}
+
+	t := &Tree{
+		data:    data,
+		layout:  body[:count*word],
+		offsets: body[count*word : (2*count+1)*word],
+		keys:    body[(2*count+1)*word:],
+		count:   int64(count),
+		codec:   codec,
+	}
+	if binary.LittleEndian.Uint64(t.offsets[count*word:]) != uint64(len(t.keys)) {
+		return nil, fmt.Errorf("%w: keys length mismatch", ErrInvalidFile)
+	}
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// validate checks that key offsets and layout ranks are within bounds so that queries
+// never slice the mapping out of range, and that all keys can be decoded and are sorted
+func (t *Tree) validate() error {
+	var prev rbtree.Comparable
+	for r := int64(0); r < t.count; r++ {
+		from := binary.LittleEndian.Uint64(t.offsets[r*word:])
+		to := binary.LittleEndian.Uint64(t.offsets[(r+1)*word:])
+		if from > to || to > uint64(len(t.keys)) {
+			return fmt.Errorf("%w: key %d offsets out of range", ErrInvalidFile, r)
+		}
+		if uint64(t.rankAt(r+1)) >= uint64(t.count) {
+			return fmt.Errorf("%w: layout rank out of range", ErrInvalidFile)
+		}
+		c, err := t.codec.DecodeKey(t.keys[from:to])
+		if err != nil {
+			return fmt.Errorf("%w: key %d: %w", ErrInvalidFile, r, err)
+		}
+		if prev != nil && c.Less(prev) {
+			return fmt.Errorf("%w: keys aren't sorted", ErrInvalidFile)
+		}
+		prev = c
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package mapped
+
+import (
+	"encoding/binary"
+	"github.com/aegoroff/godatastruct/rbtree"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+// This file contains mapped tree writer implementation
+
+// Write serializes all keys of the tree specified using codec into w.
+// The body is built in memory first because its checksum is written into the header
+func Write(w io.Writer, t rbtree.RbTree, codec rbtree.KeyCodec) error {
+	if codec == nil {
+		return rbtree.ErrNoKeyCodec
+	}
+
+	count := t.Len()
+	layout := make([]byte, count*word)
+	offsets := make([]byte, (count+1)*word)
+	var keys []byte
+
+	var rank int64
+	var err error
+	rbtree.NewAscend(t).Foreach(func(c rbtree.Comparable) {
+		if err != nil {
+			return
+		}
+		binary.LittleEndian.PutUint64(offsets[rank*word:], uint64(len(keys)))
+		keys, err = codec.EncodeKey(keys, c)
+		rank++
+	})
+	if err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint64(offsets[count*word:], uint64(len(keys)))
+
+	rank = 0
+	var fill func(k int64)
+	fill = func(k int64) {
+		if k > count {
+			return
+		}
+		fill(2 * k)
+		binary.LittleEndian.PutUint64(layout[(k-1)*word:], uint64(rank))
+		rank++
+		fill(2*k + 1)
+	}
+	fill(1)
+
+	crc := crc32.New(castagnoli)
+	for _, part := range [][]byte{layout, offsets, keys} {
+		_, _ = crc.Write(part)
+	}
+
+	header := make([]byte, headerSize)
+	copy(header, magic)
+	binary.LittleEndian.PutUint16(header[4:], Version)
+	binary.LittleEndian.PutUint64(header[8:], uint64(count))
+	binary.LittleEndian.PutUint64(header[16:], uint64(len(layout)+len(offsets)+len(keys)))
+	binary.LittleEndian.PutUint32(header[24:], crc.Sum32())
+
+	for _, part := range [][]byte{header, layout, offsets, keys} {
+		if _, err := w.Write(part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteFile serializes all keys of the tree specified using codec into the file specified.
+// The file is created or truncated and synced to disk before closing
+func WriteFile(path string, t rbtree.RbTree, codec rbtree.KeyCodec) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = Write(f, t, codec)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -1,5 +1,7 @@
 package rbtree
 
+import "math/bits"
+
 // This file contains all RB tree modification methods implementations
 
 // Insert inserts new node into Red-Black tree. Creates Root if tree is empty
@@ -9,6 +11,8 @@ func (tree *rbTree) Insert(z Comparable) {
 	}
 	n := newNode(z)
 	tree.insert(n)
+	tree.record(EventInsert, n, nil)
+	tree.notify(EventInsert, z, nil, n)
 }
 
 // ReplaceOrInsert inserts new node into Red-Black tree. Creates Root if tree is empty
@@ -17,15 +21,54 @@ func (tree *rbTree) ReplaceOrInsert(z Comparable) Comparable {
 		return nil
 	}
 
-	var r Comparable
 	n, ok := tree.SearchNode(z)
-	if ok {
-		tree.delete(n)
-		r = n.key
+	if !ok {
+		tree.Insert(z)
+		return nil
+	}
+
+	tree.delete(n)
+	tree.record(EventDelete, n, nil)
+	inserted := newNode(z)
+	tree.insert(inserted)
+	tree.record(EventInsert, inserted, nil)
+	tree.notify(EventReplace, z, n.key, inserted)
+	return n.key
+}
+
+// build replaces tree content with sorted keys specified in linear time.
+// The tree built is perfectly balanced: all nodes are black except the deepest level
+// nodes of not complete tree that are red so black height is the same for all paths
+func (tree *rbTree) build(keys []Comparable) {
+	tree.record(EventReset, tree.root, nil)
+	defer tree.notify(EventReset, nil, nil, nil)
+	if len(keys) == 0 {
+		tree.root = nil
+		return
 	}
 
-	tree.insert(newNode(z))
-	return r
+	n := uint(len(keys))
+	redDepth := -1
+	if (n+1)&n != 0 {
+		redDepth = bits.Len(n) - 1
+	}
+	tree.root = tree.buildSubtree(keys, tree.tnil, 0, redDepth)
+}
+
+func (tree *rbTree) buildSubtree(keys []Comparable, parent *Node, depth int, redDepth int) *Node {
+	if len(keys) == 0 {
+		return tree.tnil
+	}
+
+	mid := len(keys) / 2
+	n := &Node{key: keys[mid], size: int64(len(keys)), parent: parent, color: black}
+	if depth == redDepth {
+		n.color = red
+	}
+	n.left = tree.buildSubtree(keys[:mid], n, depth+1, redDepth)
+	n.right = tree.buildSubtree(keys[mid+1:], n, depth+1, redDepth)
+	n.hash = n.left.hash + n.right.hash + tree.keyHash(n.key)
+	return n
 }
 
 func newNode(z Comparable) *Node {
@@ -40,14 +83,17 @@ func (tree *rbTree) insert(z *Node) {
 		tree.root.left = tree.tnil
 		tree.root.right = tree.tnil
 		tree.root.size = 1
+		tree.root.hash = tree.keyHash(z.key)
 		return
 	}
 	y := tree.tnil
 	x := tree.root
 	z.size = 1
+	z.hash = tree.keyHash(z.key)
 	for x != tree.tnil {
 		y = x
 		y.size++
+		y.hash += z.hash
 		if z.key.Less(x.key) {
 			x = x.left
 		} else {
@@ -114,6 +160,8 @@ func (tree *rbTree) Delete(c Comparable) bool {
 	found, ok := tree.root.search(c)
 	if ok {
 		tree.delete(found)
+		tree.record(EventDelete, found, nil)
+		tree.notify(EventDelete, found.key, nil, found)
 	}
 	return ok
 }
@@ -129,6 +177,63 @@ func (tree *rbTree) DeleteAll(c Comparable) bool {
 	return res
 }
 
+// DeleteNode deletes node specified from Red-black tree
+// It returns true if node was successfully deleted otherwise false
+func (tree *rbTree) DeleteNode(n *Node) bool {
+	if !tree.contains(n) {
+		return false
+	}
+	tree.delete(n)
+	tree.record(EventDelete, n, nil)
+	tree.notify(EventDelete, n.key, nil, n)
+	return true
+}
+
+// UpdateKey changes the key of node specified. If the new key breaks nodes order
+// the node is removed and inserted again into the right position
+func (tree *rbTree) UpdateKey(n *Node, key Comparable) bool {
+	if key == nil || !tree.contains(n) {
+		return false
+	}
+
+	tree.record(EventUpdate, n, n.key)
+	tree.updateKey(n, key)
+	return true
+}
+
+func (tree *rbTree) updateKey(n *Node, key Comparable) {
+	old := n.key
+	p := n.Predecessor()
+	s := n.Successor()
+	if (p == nil || !key.Less(p.key)) && (s == nil || !s.key.Less(key)) {
+		delta := tree.keyHash(key) - tree.keyHash(n.key)
+		n.key = key
+		for x := n; x != tree.tnil; x = x.parent {
+			x.hash += delta
+		}
+		tree.notify(EventUpdate, key, old, n)
+		return
+	}
+
+	tree.delete(n)
+	n.key = key
+	tree.insert(n)
+	tree.notify(EventUpdate, key, old, n)
+}
+
+// contains checks whether node specified belongs to the tree walking up to the root
+func (tree *rbTree) contains(n *Node) bool {
+	if n.isNil() || n.parent == nil {
+		return false
+	}
+
+	x := n
+	for x.parent.isNotNil() {
+		x = x.parent
+	}
+	return x.parent == tree.tnil && x == tree.root
+}
+
 func (tree *rbTree) delete(z *Node) {
 	if z == nil || z.parent == nil {
 		return
@@ -136,22 +241,23 @@ func (tree *rbTree) delete(z *Node) {
 
 	y := z
 
-	p := z.parent
-	for p != tree.tnil {
-		p.size--
-		p = p.parent
-	}
-
 	var x *Node
 	yOriginalColor := y.color
 	if z.left == tree.tnil {
+		tree.decrementSizes(z.parent, tree.tnil, z.ownHash())
 		x = z.right
 		rbTransplant(tree, z, z.right)
 	} else if z.right == tree.tnil {
+		tree.decrementSizes(z.parent, tree.tnil, z.ownHash())
 		x = z.left
 		rbTransplant(tree, z, z.left)
 	} else {
 		y := z.right.minimum()
+		// y is removed from its place so all nodes from y's parent up to z lose y
+		// and z with all nodes up to the root lose z
+		hz := z.ownHash()
+		tree.decrementSizes(y.parent, z, y.ownHash())
+		tree.decrementSizes(z, tree.tnil, hz)
 		yOriginalColor = y.color
 		x = y.right
 		if y.parent == z {
@@ -165,10 +271,27 @@ func (tree *rbTree) delete(z *Node) {
 		y.left = z.left
 		y.left.parent = y
 		y.color = z.color
+		y.size = z.size
+		y.hash = z.hash
 	}
 	if yOriginalColor == black {
 		rbDeleteFixup(tree, x)
 	}
+
+	// deleted node must not reference tree nodes anymore
+	z.parent = nil
+	z.left = nil
+	z.right = nil
+}
+
+// decrementSizes removes one node with own hash h from subtree sizes and hashes
+// of all nodes starting from p up to (but not including) stop
+func (tree *rbTree) decrementSizes(p *Node, stop *Node, h uint64) {
+	for p != stop {
+		p.size--
+		p.hash -= h
+		p = p.parent
+	}
 }
 
 func rbDeleteFixup(tree *rbTree, x *Node) {
@@ -233,7 +356,6 @@ func rbDeleteFixup(tree *rbTree, x *Node) {
 func rbTransplant(tree *rbTree, u *Node, v *Node) {
 	if u.parent == tree.tnil {
 		tree.root = v
-		tree.root.size = u.size - 1
 	} else if u == u.parent.left {
 		u.parent.left = v
 	} else {
@@ -262,6 +384,9 @@ func leftRotate(tree *rbTree, x *Node) {
 
 	y.size = x.size
 	x.size = x.left.size + x.right.size + 1
+	y.hash, x.hash = x.hash, x.hash-y.hash+x.right.hash
+
+	tree.notify(EventRotateLeft, nil, nil, x)
 }
 
 func rightRotate(tree *rbTree, x *Node) {
@@ -284,4 +409,7 @@ func rightRotate(tree *rbTree, x *Node) {
 
 	y.size = x.size
 	x.size = x.left.size + x.right.size + 1
+	y.hash, x.hash = x.hash, x.hash-y.hash+x.left.hash
+
+	tree.notify(EventRotateRight, nil, nil, x)
 }
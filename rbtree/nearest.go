@@ -0,0 +1,68 @@
+package rbtree
+
+import "math"
+
+// This file contains nearest neighbor search implementation
+
+// Distance calculates non negative distance between two keys. It must not decrease while
+// moving away from x in key order, i.e. for keys a <= b <= x distance(a, x) >= distance(b, x)
+// and for keys x <= a <= b distance(a, x) <= distance(b, x)
+type Distance func(x Comparable, y Comparable) float64
+
+// NumericDistance is the Distance between built-in numeric keys (integer and float key types)
+// that is the absolute value of their difference. Non numeric keys have infinite distance
+func NumericDistance(x Comparable, y Comparable) float64 {
+	a, ok := numeric(x)
+	if !ok {
+		return math.Inf(1)
+	}
+	b, ok := numeric(y)
+	if !ok {
+		return math.Inf(1)
+	}
+	return math.Abs(a - b)
+}
+
+// Nearest gets the key closest to x using distance specified. If several keys
+// have the same distance the least one is returned. It runs in O(log n)
+func Nearest(t RbTree, x Comparable, distance Distance) (Comparable, bool) {
+	if x == nil {
+		return nil, false
+	}
+	result := KNearest(t, x, 1, distance)
+	if len(result) == 0 {
+		return nil, false
+	}
+	return result[0], true
+}
+
+// KNearest gets up to k keys closest to x using distance specified ordered by distance.
+// Keys with the same distance are ordered ascending so the result is deterministic.
+// It runs in O(log n + k)
+func KNearest(t RbTree, x Comparable, k int, distance Distance) []Comparable {
+	if x == nil || k <= 0 || t.Len() == 0 {
+		return nil
+	}
+
+	hi, _ := t.FindFirst(func(c Comparable) bool {
+		return !c.Less(x)
+	})
+	var lo *Node
+	if hi == nil {
+		lo = t.Maximum()
+	} else {
+		lo = hi.Predecessor()
+	}
+
+	result := make([]Comparable, 0, min(int64(k), t.Len()))
+	for len(result) < k && (lo != nil || hi != nil) {
+		if hi == nil || (lo != nil && distance(lo.key, x) <= distance(hi.key, x)) {
+			result = append(result, lo.key)
+			lo = lo.Predecessor()
+		} else {
+			result = append(result, hi.key)
+			hi = hi.Successor()
+		}
+	}
+	return result
+}
@@ -0,0 +1,158 @@
+package rbtree
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func Test_Nearest(t *testing.T) {
+	var tests = []struct {
+		x        int
+		expected int
+	}{
+		{-100, 2},
+		{2, 2},
+		{5, 4},
+		{8, 7},
+		{11, 9},
+		{14, 13},
+		{16, 15},
+		{100, 20},
+	}
+	for _, test := range tests {
+		// Arrange
+		ass := assert.New(t)
+		tree := newIntTestTree()
+
+		// Act
+		found, ok := Nearest(tree, Int(test.x), NumericDistance)
+
+		// Assert
+		ass.True(ok)
+		ass.Equal(Int(test.expected), found, "x = %d", test.x)
+	}
+}
+
+func Test_Nearest_EmptyTree_NotFound(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+
+	// Act
+	found, ok := Nearest(tree, Int(1), NumericDistance)
+
+	// Assert
+	ass.False(ok)
+	ass.Nil(found)
+}
+
+func Test_Nearest_NilKey_NotFound(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTestTree()
+
+	// Act
+	found, ok := Nearest(tree, nil, NumericDistance)
+	result := KNearest(tree, nil, 3, NumericDistance)
+
+	// Assert
+	ass.False(ok)
+	ass.Nil(found)
+	ass.Nil(result)
+}
+
+func Test_KNearest_OrderedByDistanceTiesAscending(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTestTree()
+
+	// Act
+	result := KNearest(tree, Int(11), 5, NumericDistance)
+
+	// Assert
+	ass.Equal([]Comparable{Int(9), Int(13), Int(7), Int(15), Int(6)}, result)
+}
+
+func Test_KNearest_KGreaterThanLen_AllKeys(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTree([]int{1, 5, 3})
+
+	// Act
+	result := KNearest(tree, Int(4), 10, NumericDistance)
+
+	// Assert
+	ass.Equal([]Comparable{Int(3), Int(5), Int(1)}, result)
+	ass.Empty(KNearest(tree, Int(4), 0, NumericDistance))
+}
+
+func Test_KNearest_FloatKeysCustomDistance(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	for _, v := range []float64{0.5, 1.5, 2.25, 10} {
+		tree.Insert(Float64(v))
+	}
+	squared := func(x, y Comparable) float64 {
+		d := GetFloat64(x) - GetFloat64(y)
+		return d * d
+	}
+
+	// Act
+	result := KNearest(tree, Float64(2), 3, squared)
+
+	// Assert
+	ass.Equal([]Comparable{Float64(2.25), Float64(1.5), Float64(0.5)}, result)
+}
+
+func Test_KNearest_RandomKeys_EqualToSortByDistance(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	keys := make([]int, 300)
+	for i := range keys {
+		keys[i] = rand.Intn(1000)
+		tree.Insert(Int(keys[i]))
+	}
+
+	for i := 0; i < 50; i++ {
+		x := rand.Intn(1100) - 50
+		k := rand.Intn(20) + 1
+
+		// Act
+		result := KNearest(tree, Int(x), k, NumericDistance)
+
+		// Assert
+		sort.Slice(keys, func(a, b int) bool {
+			da := math.Abs(float64(keys[a] - x))
+			db := math.Abs(float64(keys[b] - x))
+			if da != db {
+				return da < db
+			}
+			return keys[a] < keys[b]
+		})
+		expected := make([]Comparable, k)
+		for j := 0; j < k; j++ {
+			expected[j] = Int(keys[j])
+		}
+		ass.Equal(expected, result, "x = %d, k = %d", x, k)
+	}
+}
+
+func Test_NumericDistance_NotNumeric_Infinite(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+
+	// Act
+	d1 := NumericDistance(NewString("a"), Int(1))
+	d2 := NumericDistance(Int(1), NewString("a"))
+	d3 := NumericDistance(Int64(-3), Int64(4))
+
+	// Assert
+	ass.True(math.IsInf(d1, 1))
+	ass.True(math.IsInf(d2, 1))
+	ass.Equal(7.0, d3)
+}
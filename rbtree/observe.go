@@ -0,0 +1,118 @@
+package rbtree
+
+// This file contains all RB tree observer methods implementations
+
+// EventKind defines tree modification event kind. Kinds are bit flags so they can be combined
+// to subscribe several kinds of events at once
+type EventKind int
+
+const (
+	// EventInsert fired when new key inserted by Insert or ReplaceOrInsert
+	EventInsert EventKind = 1 << iota
+
+	// EventReplace fired when ReplaceOrInsert replaced existing key
+	EventReplace
+
+	// EventDelete fired for every node deleted
+	EventDelete
+
+	// EventUpdate fired when node's key changed by UpdateKey
+	EventUpdate
+
+	// EventReset fired when whole tree content replaced (for example by unmarshaling)
+	EventReset
+
+	// EventRotateLeft fired on every left rotation made during rebalancing
+	EventRotateLeft
+
+	// EventRotateRight fired on every right rotation made during rebalancing
+	EventRotateRight
+)
+
+const (
+	// EventMutations includes all events that change tree content
+	EventMutations = EventInsert | EventReplace | EventDelete | EventUpdate | EventReset
+
+	// EventRotations includes all rotation events
+	EventRotations = EventRotateLeft | EventRotateRight
+
+	// EventAll includes all events
+	EventAll = EventMutations | EventRotations
+)
+
+// Event describes tree modification
+type Event struct {
+	// Kind is the event kind
+	Kind EventKind
+
+	// Key is inserted, deleted or new key. It's nil for EventReset and rotation events
+	Key Comparable
+
+	// Replaced is the key replaced by ReplaceOrInsert or the old key changed by UpdateKey
+	Replaced Comparable
+
+	// Node is the node affected. For rotations it's the node that moved down
+	Node *Node
+}
+
+// EventHandler defines function prototype that is called on tree modification events.
+// Handlers are called synchronously after modification completed (rotations are reported
+// while rebalancing is in progress) so they must not modify the tree
+type EventHandler func(e Event)
+
+type subscription struct {
+	handler EventHandler
+	events  EventKind
+}
+
+// Subscribe registers handler for events specified and returns function that cancels subscription
+func (tree *rbTree) Subscribe(handler EventHandler, events EventKind) func() {
+	s := &subscription{handler: handler, events: events}
+	tree.observers = append(tree.observers[:len(tree.observers):len(tree.observers)], s)
+
+	return func() {
+		for i, o := range tree.observers {
+			if o == s {
+				observers := make([]*subscription, 0, len(tree.observers)-1)
+				observers = append(observers, tree.observers[:i]...)
+				tree.observers = append(observers, tree.observers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// notify calls all handlers subscribed to the event kind specified
+func (tree *rbTree) notify(kind EventKind, key Comparable, replaced Comparable, n *Node) {
+	if len(tree.observers) == 0 {
+		return
+	}
+	e := Event{Kind: kind, Key: key, Replaced: replaced, Node: n}
+	for _, o := range tree.observers {
+		if o.events&kind != 0 {
+			o.handler(e)
+		}
+	}
+}
+
+// String gets event kind name
+func (k EventKind) String() string {
+	switch k {
+	case EventInsert:
+		return "insert"
+	case EventReplace:
+		return "replace"
+	case EventDelete:
+		return "delete"
+	case EventUpdate:
+		return "update"
+	case EventReset:
+		return "reset"
+	case EventRotateLeft:
+		return "rotate left"
+	case EventRotateRight:
+		return "rotate right"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,127 @@
+package rbtree
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_Subscribe_MutationEvents(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	var events []Event
+	tree.Subscribe(func(e Event) { events = append(events, e) }, EventMutations)
+
+	// Act
+	tree.Insert(Int(1))
+	tree.Insert(Int(2))
+	tree.Insert(Int(3))
+	tree.ReplaceOrInsert(Int(2))
+	tree.ReplaceOrInsert(Int(4))
+	tree.Delete(Int(1))
+	tree.Delete(Int(100))
+	n, _ := tree.SearchNode(Int(3))
+	tree.UpdateKey(n, Int(5))
+	n, _ = tree.SearchNode(Int(4))
+	tree.DeleteNode(n)
+
+	// Assert
+	ass.Len(events, 8)
+	kinds := make([]EventKind, len(events))
+	for i, e := range events {
+		kinds[i] = e.Kind
+	}
+	ass.Equal([]EventKind{EventInsert, EventInsert, EventInsert, EventReplace, EventInsert, EventDelete, EventUpdate, EventDelete}, kinds)
+	ass.Equal(Int(2), events[3].Key)
+	ass.Equal(Int(2), events[3].Replaced)
+	ass.Equal(Int(1), events[5].Key)
+	ass.Equal(Int(5), events[6].Key)
+	ass.Equal(Int(3), events[6].Replaced)
+	ass.Equal(Int(4), events[7].Key)
+}
+
+func Test_Subscribe_RotationsOnlyWhenRequested(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	mutations := 0
+	rotations := 0
+	tree.Subscribe(func(e Event) { mutations++ }, EventMutations)
+	tree.Subscribe(func(e Event) {
+		rotations++
+		ass.NotNil(e.Node)
+	}, EventRotations)
+
+	// Act
+	for i := 0; i < 10; i++ {
+		tree.Insert(Int(i))
+	}
+
+	// Assert
+	ass.Equal(10, mutations)
+	ass.Greater(rotations, 0)
+}
+
+func Test_Subscribe_Unsubscribe_NoMoreEvents(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	first := 0
+	second := 0
+	unsubscribe := tree.Subscribe(func(e Event) { first++ }, EventAll)
+	tree.Subscribe(func(e Event) { second++ }, EventInsert)
+
+	// Act
+	tree.Insert(Int(1))
+	unsubscribe()
+	unsubscribe()
+	tree.Insert(Int(2))
+
+	// Assert
+	ass.Equal(1, first)
+	ass.Equal(2, second)
+}
+
+func Test_Subscribe_UnmarshalBinary_ResetEvent(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	src := newIntTestTree().(*rbTree)
+	data, _ := src.MarshalBinary()
+	tree := New()
+	var kinds []EventKind
+	tree.Subscribe(func(e Event) { kinds = append(kinds, e.Kind) }, EventAll)
+
+	// Act
+	_ = tree.(*rbTree).UnmarshalBinary(data)
+
+	// Assert
+	ass.Equal([]EventKind{EventReset}, kinds)
+}
+
+func Test_EventKind_String(t *testing.T) {
+	var tests = []struct {
+		kind     EventKind
+		expected string
+	}{
+		{EventInsert, "insert"},
+		{EventReplace, "replace"},
+		{EventDelete, "delete"},
+		{EventUpdate, "update"},
+		{EventReset, "reset"},
+		{EventRotateLeft, "rotate left"},
+		{EventRotateRight, "rotate right"},
+		{EventAll, "unknown"},
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+
+			// Act
+			result := test.kind.String()
+
+			// Assert
+			ass.Equal(test.expected, result)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package rbtree
+
+import "sync"
+
+// This file contains all RB tree parallel traversal implementations
+
+// rankRange walks count nodes in ascending order starting from the node specified
+type rankRange struct {
+	iterator
+	next  *Node
+	count int64
+}
+
+// Partition splits tree into k chunks of (almost) equal size by rank. Chunks are returned
+// in ascending order and each of them walks its part of the tree in ascending order too.
+// So equal keys may be split between adjacent chunks. If k is greater than tree size
+// the number of chunks is equal to tree size. Chunks may be walked concurrently
+// but the tree must not be modified while walking
+func Partition(t RbTree, k int) []Enumerable {
+	n := t.Len()
+	if n == 0 {
+		return nil
+	}
+	if k < 1 {
+		k = 1
+	}
+	if int64(k) > n {
+		k = int(n)
+	}
+
+	result := make([]Enumerable, k)
+	for i := 0; i < k; i++ {
+		from := int64(i) * n / int64(k)
+		to := int64(i+1) * n / int64(k)
+		start, _ := t.OrderStatisticSelect(from + 1)
+		e := &rankRange{
+			iterator: iterator{tree: t},
+			next:     start,
+			count:    to - from,
+		}
+		e.it = e
+		result[i] = e
+	}
+	return result
+}
+
+// ParallelForeach calls the callback for every value in the tree using k goroutines
+// each of them processes its own Partition chunk. The callback must be safe for concurrent use.
+// It returns when all values have been processed
+func ParallelForeach(t RbTree, k int, callback NodeAction) {
+	chunks := Partition(t, k)
+	var wg sync.WaitGroup
+	wg.Add(len(chunks))
+	for _, chunk := range chunks {
+		go func(e Enumerable) {
+			defer wg.Done()
+			e.Foreach(callback)
+		}(chunk)
+	}
+	wg.Wait()
+}
+
+// ParallelMap calls mapper for every value in the tree using k goroutines like ParallelForeach does
+// and merges results preserving tree order so i-th result corresponds to i-th tree value in ascending order
+func ParallelMap[R any](t RbTree, k int, mapper func(Comparable) R) []R {
+	chunks := Partition(t, k)
+	results := make([]R, t.Len())
+	var wg sync.WaitGroup
+	wg.Add(len(chunks))
+	var offset int64
+	for _, chunk := range chunks {
+		part := results[offset : offset+chunk.(*rankRange).count]
+		offset += int64(len(part))
+		go func(e Enumerable, part []R) {
+			defer wg.Done()
+			i := 0
+			e.Foreach(func(c Comparable) {
+				part[i] = mapper(c)
+				i++
+			})
+		}(chunk, part)
+	}
+	wg.Wait()
+	return results
+}
+
+func (i *rankRange) Next() bool {
+	result := i.count > 0 && i.next.isNotNil()
+	if result {
+		i.curr = i.next
+		i.next = i.curr.Successor()
+		i.count--
+	}
+	return result
+}
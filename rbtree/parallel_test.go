@@ -0,0 +1,122 @@
+package rbtree
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+)
+
+func Test_Partition_ChunksAreBalancedAndOrdered(t *testing.T) {
+	var tests = []struct {
+		size     int
+		k        int
+		expected []int
+	}{
+		{10, 3, []int{3, 3, 4}},
+		{10, 1, []int{10}},
+		{10, 10, []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1}},
+		{3, 5, []int{1, 1, 1}},
+		{5, 0, []int{5}},
+	}
+	for _, test := range tests {
+		// Arrange
+		ass := assert.New(t)
+		tree := New()
+		for i := 0; i < test.size; i++ {
+			tree.Insert(Int(i))
+		}
+
+		// Act
+		chunks := Partition(tree, test.k)
+
+		// Assert
+		sizes := make([]int, len(chunks))
+		var all []int
+		for i, chunk := range chunks {
+			chunk.Foreach(func(c Comparable) {
+				sizes[i]++
+				all = append(all, GetInt(c))
+			})
+		}
+		ass.Equal(test.expected, sizes)
+		ass.Equal(ascendInts(tree), all)
+	}
+}
+
+func Test_Partition_Duplicates_AllNodesWalkedOnce(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTree([]int{1, 2, 2, 2, 2, 2, 2, 3})
+
+	// Act
+	chunks := Partition(tree, 3)
+
+	// Assert
+	var all []int
+	for _, chunk := range chunks {
+		it := chunk.Iterator()
+		for it.Next() {
+			all = append(all, GetInt(it.Current()))
+		}
+	}
+	ass.Equal(ascendInts(tree), all)
+}
+
+func Test_Partition_EmptyTree_NoChunks(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+
+	// Act
+	chunks := Partition(tree, 4)
+
+	// Assert
+	ass.Empty(chunks)
+}
+
+func Test_ParallelForeach_AllValuesProcessed(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	for i := 1; i <= 10000; i++ {
+		tree.Insert(Int(i))
+	}
+	var sum int64
+	var count int64
+
+	// Act
+	ParallelForeach(tree, 8, func(c Comparable) {
+		atomic.AddInt64(&sum, int64(c.(Int)))
+		atomic.AddInt64(&count, 1)
+	})
+
+	// Assert
+	ass.Equal(int64(10000), count)
+	ass.Equal(int64(10000*10001/2), sum)
+}
+
+func Test_ParallelMap_ResultsInTreeOrder(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTestTree()
+
+	// Act
+	result := ParallelMap(tree, 4, func(c Comparable) int {
+		return int(c.(Int)) * 10
+	})
+
+	// Assert
+	ass.Equal([]int{20, 30, 40, 60, 70, 90, 130, 150, 170, 180, 200}, result)
+}
+
+func Test_ParallelMap_EmptyTree_EmptyResult(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+
+	// Act
+	result := ParallelMap(tree, 4, func(c Comparable) int { return 1 })
+
+	// Assert
+	ass.Empty(result)
+}
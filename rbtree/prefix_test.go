@@ -0,0 +1,103 @@
+package rbtree
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func Test_NewPrefixScan_StringKeys(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	for _, s := range []string{"user:42:name", "user:41:name", "user:42:", "user:42:age", "user:420:name", "user:43", "user:42"} {
+		tree.Insert(NewString(s))
+	}
+
+	// Act
+	result := collect(NewPrefixScan(tree, NewString("user:42:").(*String)))
+
+	// Assert
+	ass.Equal([]string{"user:42:", "user:42:age", "user:42:name"}, result)
+	ass.Equal(int64(3), CountPrefix(tree, NewString("user:42:").(*String)))
+}
+
+func Test_NewPrefixScan_BytesKeys(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	for _, b := range [][]byte{{1, 2, 3}, {1, 2}, {1, 3}, {0xff}, {1, 2, 0xff, 0}, {1}} {
+		tree.Insert(Bytes(b))
+	}
+
+	// Act
+	var result [][]byte
+	NewPrefixScan(tree, Bytes{1, 2}).Foreach(func(c Comparable) {
+		result = append(result, GetBytes(c))
+	})
+
+	// Assert
+	ass.Equal([][]byte{{1, 2}, {1, 2, 3}, {1, 2, 0xff, 0}}, result)
+	ass.Equal(int64(3), CountPrefix(tree, Bytes{1, 2}))
+	ass.Equal(int64(6), CountPrefix(tree, Bytes{}))
+	ass.Equal(int64(0), CountPrefix(tree, Bytes{2}))
+}
+
+func Test_CountPrefix_Duplicates(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	for _, s := range []string{"ab", "ab", "abc", "abc", "b", "a"} {
+		tree.Insert(NewString(s))
+	}
+
+	// Act
+	count := CountPrefix(tree, NewString("ab").(*String))
+
+	// Assert
+	ass.Equal(int64(4), count)
+}
+
+func Test_CountPrefix_EmptyTree_Zero(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+
+	// Act
+	count := CountPrefix(tree, NewString("a").(*String))
+
+	// Assert
+	ass.Equal(int64(0), count)
+}
+
+func Test_CountPrefix_RandomKeys_EqualToScan(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	keys := make([]string, 0, 500)
+	for i := 0; i < 500; i++ {
+		k := fmt.Sprintf("%x", rand.Intn(4096))
+		keys = append(keys, k)
+		tree.Insert(NewString(k))
+	}
+
+	for _, p := range []string{"", "a", "1f", "ff", "abc", "0", "g"} {
+		prefix := NewString(p).(*String)
+
+		// Act
+		count := CountPrefix(tree, prefix)
+		scanned := collect(NewPrefixScan(tree, prefix))
+
+		// Assert
+		expected := 0
+		for _, k := range keys {
+			if strings.HasPrefix(k, p) {
+				expected++
+			}
+		}
+		ass.Equal(int64(expected), count, "prefix %q", p)
+		ass.Len(scanned, expected, "prefix %q", p)
+	}
+}
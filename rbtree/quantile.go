@@ -0,0 +1,136 @@
+package rbtree
+
+import (
+	"errors"
+	"math"
+)
+
+// This file contains all RB tree quantile methods implementations
+
+var (
+	// ErrEmptyTree is returned by quantile methods when tree is empty
+	ErrEmptyTree = errors.New("tree is empty")
+
+	// ErrQuantileOutOfRange is returned when quantile is outside of [0, 1] range
+	ErrQuantileOutOfRange = errors.New("quantile must be within [0, 1] range")
+
+	// ErrNotNumeric is returned when numeric operation is applied to non numeric keys
+	ErrNotNumeric = errors.New("key is not numeric")
+)
+
+// quantileEpsilon is the relative tolerance that compensates floating point errors
+// like 0.3 * 10 = 3.0000000000000004 so that nearest rank isn't shifted by one.
+// The error of q * n grows with n so the tolerance is scaled by n
+const quantileEpsilon = 1e-12
+
+// Quantile gets the key at quantile q using nearest rank method
+func (tree *rbTree) Quantile(q float64) (Comparable, error) {
+	r, err := tree.quantileRank(q)
+	if err != nil {
+		return nil, err
+	}
+	n, _ := tree.OrderStatisticSelect(r)
+	return n.key, nil
+}
+
+// Median gets the lower median of the tree keys
+func (tree *rbTree) Median() (Comparable, error) {
+	return tree.Quantile(0.5)
+}
+
+// Percentiles gets keys at all quantiles specified
+func (tree *rbTree) Percentiles(qs ...float64) ([]Comparable, error) {
+	result := make([]Comparable, len(qs))
+	for i, q := range qs {
+		c, err := tree.Quantile(q)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = c
+	}
+	return result, nil
+}
+
+// InterpolatedQuantile gets quantile q of numeric keys using linear interpolation
+// between the closest ranks
+func (tree *rbTree) InterpolatedQuantile(q float64) (float64, error) {
+	if err := tree.validateQuantile(q); err != nil {
+		return 0, err
+	}
+
+	h := q * float64(tree.Len()-1)
+	lo := math.Floor(h)
+	n, _ := tree.OrderStatisticSelect(int64(lo) + 1)
+	x, ok := numeric(n.key)
+	if !ok {
+		return 0, ErrNotNumeric
+	}
+
+	frac := h - lo
+	if frac == 0 {
+		return x, nil
+	}
+
+	next := n.Successor()
+	y, ok := numeric(next.key)
+	if !ok {
+		return 0, ErrNotNumeric
+	}
+	return x + frac*(y-x), nil
+}
+
+func (tree *rbTree) quantileRank(q float64) (int64, error) {
+	if err := tree.validateQuantile(q); err != nil {
+		return 0, err
+	}
+	return nearestRank(q, tree.Len()), nil
+}
+
+// nearestRank gets rank (starting from 1) of quantile q among n keys
+func nearestRank(q float64, n int64) int64 {
+	r := int64(math.Ceil(q*float64(n) - quantileEpsilon*float64(n)))
+	return max(r, 1)
+}
+
+func (tree *rbTree) validateQuantile(q float64) error {
+	if tree.Len() == 0 {
+		return ErrEmptyTree
+	}
+	// the condition is written so to reject NaN too
+	if !(q >= 0 && q <= 1) {
+		return ErrQuantileOutOfRange
+	}
+	return nil
+}
+
+// numeric gets numeric value of built-in numeric keys
+func numeric(c Comparable) (float64, bool) {
+	switch v := c.(type) {
+	case Int:
+		return float64(v), true
+	case Int64:
+		return float64(v), true
+	case Int8:
+		return float64(v), true
+	case Int16:
+		return float64(v), true
+	case Int32:
+		return float64(v), true
+	case Uint:
+		return float64(v), true
+	case Uint8:
+		return float64(v), true
+	case Uint16:
+		return float64(v), true
+	case Uint32:
+		return float64(v), true
+	case Uint64:
+		return float64(v), true
+	case Float32:
+		return float64(v), true
+	case Float64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,210 @@
+package rbtree
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func Test_Quantile_ValueAsExpected(t *testing.T) {
+	// []int{2, 3, 4, 6, 7, 9, 13, 15, 17, 18, 20}
+	var tests = []struct {
+		name     string
+		q        float64
+		expected int
+	}{
+		{"0", 0, 2},
+		{"0.1", 0.1, 3},
+		{"0.25", 0.25, 4},
+		{"0.5", 0.5, 9},
+		{"0.9", 0.9, 18},
+		{"0.99", 0.99, 20},
+		{"1", 1, 20},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			tree := newIntTestTree()
+
+			// Act
+			c, err := tree.Quantile(test.q)
+
+			// Assert
+			ass.NoError(err)
+			ass.Equal(test.expected, GetInt(c))
+		})
+	}
+}
+
+func Test_QuantileExactRank_NotShiftedByRoundingError(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTree([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+
+	// Act
+	c, err := tree.Quantile(0.3)
+
+	// Assert
+	ass.NoError(err)
+	ass.Equal(3, GetInt(c))
+}
+
+func Test_NearestRankExact_NotShiftedByRoundingError(t *testing.T) {
+	var tests = []struct {
+		name     string
+		q        float64
+		n        int64
+		expected int64
+	}{
+		{"0.3 of 10", 0.3, 10, 3},
+		{"0.28 of 10^8", 0.28, 100_000_000, 28_000_000},
+		{"0.07 of 10^10", 0.07, 10_000_000_000, 700_000_000},
+		{"0.67 of 10^11", 0.67, 100_000_000_000, 67_000_000_000},
+		{"above exact rank", 0.5, 100_000_000_001, 50_000_000_001},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+
+			// Act
+			r := nearestRank(test.q, test.n)
+
+			// Assert
+			ass.Equal(test.expected, r)
+		})
+	}
+}
+
+func Test_QuantileInvalid_Error(t *testing.T) {
+	var tests = []struct {
+		name     string
+		tree     RbTree
+		q        float64
+		expected error
+	}{
+		{"empty tree", New(), 0.5, ErrEmptyTree},
+		{"negative", newIntTestTree(), -0.1, ErrQuantileOutOfRange},
+		{"greater then one", newIntTestTree(), 1.1, ErrQuantileOutOfRange},
+		{"NaN", newIntTestTree(), math.NaN(), ErrQuantileOutOfRange},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+
+			// Act
+			c, err1 := test.tree.Quantile(test.q)
+			f, err2 := test.tree.InterpolatedQuantile(test.q)
+			p, err3 := test.tree.Percentiles(0.5, test.q)
+
+			// Assert
+			ass.Nil(c)
+			ass.Equal(0.0, f)
+			ass.Nil(p)
+			ass.ErrorIs(err1, test.expected)
+			ass.ErrorIs(err2, test.expected)
+			ass.ErrorIs(err3, test.expected)
+		})
+	}
+}
+
+func Test_Median_ValueAsExpected(t *testing.T) {
+	var tests = []struct {
+		name     string
+		input    []int
+		expected int
+	}{
+		{"odd", []int{5, 1, 3}, 3},
+		{"even", []int{4, 1, 3, 2}, 2},
+		{"one", []int{7}, 7},
+		{"duplicates", []int{1, 2, 2, 2, 9}, 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			tree := newIntTree(test.input)
+
+			// Act
+			c, err := tree.Median()
+
+			// Assert
+			ass.NoError(err)
+			ass.Equal(test.expected, GetInt(c))
+		})
+	}
+}
+
+func Test_Percentiles_ValuesAsExpected(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	for i := 1; i <= 100; i++ {
+		tree.Insert(Int64(i))
+	}
+
+	// Act
+	ps, err := tree.Percentiles(0.5, 0.9, 0.99, 1)
+
+	// Assert
+	ass.NoError(err)
+	ass.Equal([]Comparable{Int64(50), Int64(90), Int64(99), Int64(100)}, ps)
+}
+
+func Test_InterpolatedQuantile_ValueAsExpected(t *testing.T) {
+	var tests = []struct {
+		name     string
+		tree     RbTree
+		q        float64
+		expected float64
+	}{
+		{"median even", newIntTree([]int{1, 2, 3, 4}), 0.5, 2.5},
+		{"median odd", newIntTree([]int{1, 2, 3}), 0.5, 2},
+		{"min", newIntTree([]int{1, 2, 3}), 0, 1},
+		{"max", newIntTree([]int{1, 2, 3}), 1, 3},
+		{"one", newIntTree([]int{5}), 0.3, 5},
+		{"quarter", newIntTree([]int{10, 20, 30, 40, 50}), 0.3, 22},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+
+			// Act
+			f, err := test.tree.InterpolatedQuantile(test.q)
+
+			// Assert
+			ass.NoError(err)
+			ass.InDelta(test.expected, f, 1e-9)
+		})
+	}
+}
+
+func Test_InterpolatedQuantileInt64_ValueAsExpected(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	tree.Insert(Int64(1))
+	tree.Insert(Int64(2))
+
+	// Act
+	f, err := tree.InterpolatedQuantile(0.5)
+
+	// Assert
+	ass.NoError(err)
+	ass.InDelta(1.5, f, 1e-9)
+}
+
+func Test_InterpolatedQuantileNotNumeric_Error(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newTestStringTree()
+
+	// Act
+	_, err := tree.InterpolatedQuantile(0.5)
+
+	// Assert
+	ass.ErrorIs(err, ErrNotNumeric)
+}
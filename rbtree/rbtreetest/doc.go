@@ -0,0 +1,11 @@
+// Package rbtreetest provides behavioral conformance test suite for rbtree.RbTree implementations
+// (for example wrappers like ones in rbtree/special). Call Run from a test passing factory
+// function that creates empty tree under test:
+//
+//	func Test_MyTree_Conformance(t *testing.T) {
+//		rbtreetest.Run(t, func() rbtree.RbTree { return NewMyTree() })
+//	}
+//
+// The suite uses rbtree.Int keys. Behavior of out of range Floor, Ceiling and open range
+// iterations is compared with rbtree.New reference implementation.
+package rbtreetest
@@ -0,0 +1,94 @@
+package rbtreetest
+
+import (
+	"github.com/aegoroff/godatastruct/rbtree"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// This file contains randomized differential check against a sorted slice
+
+const (
+	randomOperations = 3000
+	randomKeysRange  = 300
+	randomCheckEvery = 500
+)
+
+func testRandomDifferential(t *testing.T, factory Factory) {
+	ass := assert.New(t)
+	r := rand.New(rand.NewSource(1000))
+	tree := factory()
+	var expected []int
+
+	for i := 1; i <= randomOperations; i++ {
+		k := r.Intn(randomKeysRange)
+		ix := sort.SearchInts(expected, k)
+		exists := ix < len(expected) && expected[ix] == k
+		switch r.Intn(4) {
+		case 0, 1:
+			tree.Insert(rbtree.Int(k))
+			expected = insertAt(expected, ix, k)
+		case 2:
+			ass.Equal(exists, tree.Delete(rbtree.Int(k)), "delete %d", k)
+			if exists {
+				expected = append(expected[:ix], expected[ix+1:]...)
+			}
+		default:
+			old := tree.ReplaceOrInsert(rbtree.Int(k))
+			ass.Equal(exists, old != nil, "replace %d", k)
+			if !exists {
+				expected = insertAt(expected, ix, k)
+			}
+		}
+
+		if i%randomCheckEvery == 0 {
+			checkEqualToSorted(ass, tree, expected)
+		}
+	}
+}
+
+func checkEqualToSorted(ass *assert.Assertions, tree rbtree.RbTree, expected []int) {
+	ass.Equal(int64(len(expected)), tree.Len())
+	ass.Equal(expected, collect(rbtree.NewAscend(tree)))
+	ass.Equal(reversed(expected), collect(rbtree.NewDescend(tree)))
+
+	for i, k := range expected {
+		n, ok := tree.OrderStatisticSelect(int64(i + 1))
+		if !ass.True(ok, "select %d", i+1) {
+			return
+		}
+		ass.Equal(rbtree.Int(k), n.Key(), "select %d", i+1)
+	}
+
+	if len(expected) == 0 {
+		return
+	}
+	min, max := expected[0], expected[len(expected)-1]
+	for x := min; x <= max; x++ {
+		lo := sort.SearchInts(expected, x)
+		hi := sort.SearchInts(expected, x+1)
+
+		first, _, count := tree.EqualRange(rbtree.Int(x))
+		ass.Equal(int64(hi-lo), count, "count %d", x)
+		if count > 0 {
+			ass.Equal(int64(lo+1), first.Rank(), "rank %d", x)
+		}
+
+		floor, ok := tree.Floor(rbtree.Int(x))
+		ass.True(ok, "floor %d", x)
+		ass.Equal(rbtree.Int(expected[hi-1]), floor, "floor %d", x)
+
+		ceiling, ok := tree.Ceiling(rbtree.Int(x))
+		ass.True(ok, "ceiling %d", x)
+		ass.Equal(rbtree.Int(expected[lo]), ceiling, "ceiling %d", x)
+	}
+}
+
+func insertAt(keys []int, ix int, k int) []int {
+	keys = append(keys, 0)
+	copy(keys[ix+1:], keys[ix:])
+	keys[ix] = k
+	return keys
+}
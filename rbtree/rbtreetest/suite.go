@@ -0,0 +1,394 @@
+package rbtreetest
+
+import (
+	"github.com/aegoroff/godatastruct/rbtree"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// Factory creates new empty tree under test
+type Factory func() rbtree.RbTree
+
+// testKeys are inserted into the tree in this order by most checks
+var testKeys = []int{6, 18, 3, 15, 7, 2, 4, 13, 9, 17, 20}
+
+// sortedTestKeys are testKeys in ascending order
+var sortedTestKeys = []int{2, 3, 4, 6, 7, 9, 13, 15, 17, 18, 20}
+
+// Run runs the whole conformance suite against trees created by factory specified.
+// Every group of checks runs as a subtest so a single failing behavior doesn't hide others
+func Run(t *testing.T, factory Factory) {
+	t.Run("EmptyTree", func(t *testing.T) { testEmptyTree(t, factory) })
+	t.Run("Ordering", func(t *testing.T) { testOrdering(t, factory) })
+	t.Run("Duplicates", func(t *testing.T) { testDuplicates(t, factory) })
+	t.Run("ReplaceOrInsert", func(t *testing.T) { testReplaceOrInsert(t, factory) })
+	t.Run("NilKeys", func(t *testing.T) { testNilKeys(t, factory) })
+	t.Run("FloorCeiling", func(t *testing.T) { testFloorCeiling(t, factory) })
+	t.Run("OrderStatistics", func(t *testing.T) { testOrderStatistics(t, factory) })
+	t.Run("BinarySearch", func(t *testing.T) { testBinarySearch(t, factory) })
+	t.Run("FingerSearch", func(t *testing.T) { testFingerSearch(t, factory) })
+	t.Run("NodeOperations", func(t *testing.T) { testNodeOperations(t, factory) })
+	t.Run("Walks", func(t *testing.T) { testWalks(t, factory) })
+	t.Run("Ranges", func(t *testing.T) { testRanges(t, factory) })
+	t.Run("Iterator", func(t *testing.T) { testIterator(t, factory) })
+	t.Run("Transactions", func(t *testing.T) { testTransactions(t, factory) })
+	t.Run("Subscribe", func(t *testing.T) { testSubscribe(t, factory) })
+	t.Run("RandomDifferential", func(t *testing.T) { testRandomDifferential(t, factory) })
+}
+
+func testEmptyTree(t *testing.T, factory Factory) {
+	ass := assert.New(t)
+	tree := factory()
+
+	ass.Equal(int64(0), tree.Len())
+	_, found := tree.Search(rbtree.Int(1))
+	ass.False(found)
+	_, found = tree.Floor(rbtree.Int(1))
+	ass.False(found)
+	_, found = tree.Ceiling(rbtree.Int(1))
+	ass.False(found)
+	ass.Nil(tree.Minimum())
+	ass.Nil(tree.Maximum())
+	_, found = tree.OrderStatisticSelect(1)
+	ass.False(found)
+	ass.False(tree.Delete(rbtree.Int(1)))
+	ass.False(tree.DeleteAll(rbtree.Int(1)))
+	_, err := tree.Median()
+	ass.ErrorIs(err, rbtree.ErrEmptyTree)
+	ass.Empty(collect(rbtree.NewAscend(tree)))
+	ass.Empty(collect(rbtree.NewDescend(tree)))
+	ass.Empty(collect(rbtree.NewWalkPreorder(tree)))
+	ass.Empty(collect(rbtree.NewWalkPostorder(tree)))
+	ass.Empty(collect(rbtree.NewWalkLevelOrder(tree)))
+	ass.Empty(collect(rbtree.NewOpenAscendRange(tree, rbtree.Int(1), rbtree.Int(10))))
+	ass.Empty(collect(rbtree.NewOpenDescendRange(tree, rbtree.Int(10), rbtree.Int(1))))
+}
+
+func testOrdering(t *testing.T, factory Factory) {
+	ass := assert.New(t)
+	tree := newTree(factory, testKeys)
+
+	ass.Equal(int64(len(testKeys)), tree.Len())
+	ass.Equal(sortedTestKeys, collect(rbtree.NewAscend(tree)))
+	ass.Equal(reversed(sortedTestKeys), collect(rbtree.NewDescend(tree)))
+	ass.Equal(rbtree.Int(2), tree.Minimum().Key())
+	ass.Equal(rbtree.Int(20), tree.Maximum().Key())
+	for _, k := range testKeys {
+		found, ok := tree.Search(rbtree.Int(k))
+		ass.True(ok, "key %d", k)
+		ass.Equal(rbtree.Int(k), found)
+	}
+	_, ok := tree.Search(rbtree.Int(5))
+	ass.False(ok)
+
+	ass.True(tree.Delete(rbtree.Int(15)))
+	ass.False(tree.Delete(rbtree.Int(15)))
+	ass.Equal([]int{2, 3, 4, 6, 7, 9, 13, 17, 18, 20}, collect(rbtree.NewAscend(tree)))
+}
+
+func testDuplicates(t *testing.T, factory Factory) {
+	ass := assert.New(t)
+	tree := newTree(factory, []int{5, 1, 5, 3, 5, 7, 1})
+
+	ass.Equal(int64(7), tree.Len())
+	ass.Equal([]int{1, 1, 3, 5, 5, 5, 7}, collect(rbtree.NewAscend(tree)))
+
+	first, last, count := tree.EqualRange(rbtree.Int(5))
+	ass.Equal(int64(3), count)
+	ass.Equal(int64(4), first.Rank())
+	ass.Equal(int64(6), last.Rank())
+	ass.Equal([]int{5, 5, 5}, collect(rbtree.NewEqualRange(tree, rbtree.Int(5))))
+	ass.Len(tree.SearchAll(rbtree.Int(1)), 2)
+
+	ass.True(tree.Delete(rbtree.Int(5)))
+	ass.Equal(int64(6), tree.Len())
+	ass.True(tree.DeleteAll(rbtree.Int(5)))
+	ass.Equal([]int{1, 1, 3, 7}, collect(rbtree.NewAscend(tree)))
+	_, _, count = tree.EqualRange(rbtree.Int(5))
+	ass.Equal(int64(0), count)
+}
+
+func testReplaceOrInsert(t *testing.T, factory Factory) {
+	ass := assert.New(t)
+	tree := newTree(factory, []int{1, 2})
+
+	ass.Nil(tree.ReplaceOrInsert(rbtree.Int(3)))
+	ass.Equal(rbtree.Int(2), tree.ReplaceOrInsert(rbtree.Int(2)))
+	ass.Equal(int64(3), tree.Len())
+	ass.Equal([]int{1, 2, 3}, collect(rbtree.NewAscend(tree)))
+}
+
+func testNilKeys(t *testing.T, factory Factory) {
+	ass := assert.New(t)
+	tree := newTree(factory, []int{1, 2})
+
+	tree.Insert(nil)
+	ass.Nil(tree.ReplaceOrInsert(nil))
+	ass.Equal(int64(2), tree.Len())
+	_, found := tree.Search(nil)
+	ass.False(found)
+	ass.False(tree.Delete(nil))
+	_, found = tree.Floor(nil)
+	ass.False(found)
+	_, found = tree.Ceiling(nil)
+	ass.False(found)
+}
+
+func testFloorCeiling(t *testing.T, factory Factory) {
+	ass := assert.New(t)
+	tree := newTree(factory, testKeys)
+	reference := newTree(rbtree.New, testKeys)
+
+	var tests = []struct {
+		x       int
+		floor   int
+		ceiling int
+	}{
+		{2, 2, 2},
+		{5, 4, 6},
+		{10, 9, 13},
+		{14, 13, 15},
+		{19, 18, 20},
+		{20, 20, 20},
+	}
+	for _, test := range tests {
+		floor, floorOk := tree.Floor(rbtree.Int(test.x))
+		ceiling, ceilingOk := tree.Ceiling(rbtree.Int(test.x))
+		ass.True(floorOk, "x = %d", test.x)
+		ass.Equal(rbtree.Int(test.floor), floor, "x = %d", test.x)
+		ass.True(ceilingOk, "x = %d", test.x)
+		ass.Equal(rbtree.Int(test.ceiling), ceiling, "x = %d", test.x)
+	}
+
+	for _, x := range []int{-100, 1, 21, 100} {
+		floor, floorOk := tree.Floor(rbtree.Int(x))
+		expectedFloor, expectedFloorOk := reference.Floor(rbtree.Int(x))
+		ass.Equal(expectedFloorOk, floorOk, "x = %d", x)
+		ass.Equal(expectedFloor, floor, "x = %d", x)
+
+		ceiling, ceilingOk := tree.Ceiling(rbtree.Int(x))
+		expectedCeiling, expectedCeilingOk := reference.Ceiling(rbtree.Int(x))
+		ass.Equal(expectedCeilingOk, ceilingOk, "x = %d", x)
+		ass.Equal(expectedCeiling, ceiling, "x = %d", x)
+	}
+}
+
+func testOrderStatistics(t *testing.T, factory Factory) {
+	ass := assert.New(t)
+	tree := newTree(factory, testKeys)
+
+	for i, k := range sortedTestKeys {
+		n, ok := tree.OrderStatisticSelect(int64(i + 1))
+		ass.True(ok, "i = %d", i+1)
+		ass.Equal(rbtree.Int(k), n.Key(), "i = %d", i+1)
+		ass.Equal(int64(i+1), n.Rank(), "i = %d", i+1)
+	}
+	_, ok := tree.OrderStatisticSelect(0)
+	ass.False(ok)
+	_, ok = tree.OrderStatisticSelect(tree.Len() + 1)
+	ass.False(ok)
+	ass.Equal(tree.Len(), tree.Root().Size())
+
+	median, err := tree.Median()
+	ass.NoError(err)
+	ass.Equal(rbtree.Int(9), median)
+	q, err := tree.Quantile(1)
+	ass.NoError(err)
+	ass.Equal(rbtree.Int(20), q)
+	_, err = tree.Quantile(2)
+	ass.ErrorIs(err, rbtree.ErrQuantileOutOfRange)
+}
+
+func testBinarySearch(t *testing.T, factory Factory) {
+	ass := assert.New(t)
+	tree := newTree(factory, testKeys)
+	greater := func(c rbtree.Comparable) bool { return rbtree.GetInt(c) > 10 }
+	lesser := func(c rbtree.Comparable) bool { return rbtree.GetInt(c) < 10 }
+
+	first, ok := tree.FindFirst(greater)
+	ass.True(ok)
+	ass.Equal(rbtree.Int(13), first.Key())
+	last, ok := tree.FindLast(lesser)
+	ass.True(ok)
+	ass.Equal(rbtree.Int(9), last.Key())
+	ass.Equal(int64(6), tree.PartitionPoint(greater))
+
+	_, ok = tree.FindFirst(func(rbtree.Comparable) bool { return false })
+	ass.False(ok)
+	ass.Equal(tree.Len(), tree.PartitionPoint(func(rbtree.Comparable) bool { return false }))
+}
+
+func testFingerSearch(t *testing.T, factory Factory) {
+	ass := assert.New(t)
+	tree := newTree(factory, testKeys)
+
+	finger := tree.Minimum()
+	for x := 0; x <= 22; x++ {
+		value := rbtree.Int(x)
+		n, ok := tree.SearchFrom(finger, value)
+		_, expectedOk := tree.Search(value)
+		ass.Equal(expectedOk, ok, "x = %d", x)
+		if ok {
+			ass.Equal(value, n.Key())
+			finger = n
+		}
+
+		floor, floorOk := tree.FloorFrom(finger, value)
+		expectedFloor, expectedFloorOk := tree.Floor(value)
+		ass.Equal(expectedFloorOk, floorOk, "x = %d", x)
+		if floorOk {
+			ass.Equal(expectedFloor, floor.Key(), "x = %d", x)
+		}
+
+		ceiling, ceilingOk := tree.CeilingFrom(finger, value)
+		expectedCeiling, expectedCeilingOk := tree.Ceiling(value)
+		ass.Equal(expectedCeilingOk, ceilingOk, "x = %d", x)
+		if ceilingOk {
+			ass.Equal(expectedCeiling, ceiling.Key(), "x = %d", x)
+		}
+	}
+}
+
+func testNodeOperations(t *testing.T, factory Factory) {
+	ass := assert.New(t)
+	tree := newTree(factory, testKeys)
+
+	n, ok := tree.SearchNode(rbtree.Int(7))
+	ass.True(ok)
+	ass.Equal(rbtree.Int(6), n.Predecessor().Key())
+	ass.Equal(rbtree.Int(9), n.Successor().Key())
+	ass.Nil(tree.Maximum().Successor())
+	ass.Nil(tree.Minimum().Predecessor())
+
+	ass.True(tree.UpdateKey(n, rbtree.Int(19)))
+	ass.Equal([]int{2, 3, 4, 6, 9, 13, 15, 17, 18, 19, 20}, collect(rbtree.NewAscend(tree)))
+
+	n, _ = tree.SearchNode(rbtree.Int(13))
+	ass.True(tree.DeleteNode(n))
+	ass.Equal([]int{2, 3, 4, 6, 9, 15, 17, 18, 19, 20}, collect(rbtree.NewAscend(tree)))
+	ass.False(tree.DeleteNode(nil))
+}
+
+func testWalks(t *testing.T, factory Factory) {
+	ass := assert.New(t)
+	tree := newTree(factory, testKeys)
+	root := rbtree.GetInt(tree.Root().Key())
+
+	inorder := collect(rbtree.NewWalkInorder(tree))
+	preorder := collect(rbtree.NewWalkPreorder(tree))
+	postorder := collect(rbtree.NewWalkPostorder(tree))
+	levelorder := collect(rbtree.NewWalkLevelOrder(tree))
+
+	ass.Equal(sortedTestKeys, inorder)
+	ass.ElementsMatch(testKeys, preorder)
+	ass.ElementsMatch(testKeys, postorder)
+	ass.ElementsMatch(testKeys, levelorder)
+	ass.Equal(root, preorder[0])
+	ass.Equal(root, levelorder[0])
+	ass.Equal(root, postorder[len(postorder)-1])
+
+	var depths []int
+	rbtree.NewDepthWalkLevelOrder(tree).Foreach(func(n *rbtree.Node, depth int, _ rbtree.Color) {
+		depths = append(depths, depth)
+	})
+	ass.Len(depths, len(testKeys))
+	ass.Equal(0, depths[0])
+	for i := 1; i < len(depths); i++ {
+		ass.True(depths[i] > 0 && depths[i] >= depths[i-1], "level order depths must not decrease")
+	}
+}
+
+func testRanges(t *testing.T, factory Factory) {
+	ass := assert.New(t)
+	tree := newTree(factory, testKeys)
+	reference := newTree(rbtree.New, testKeys)
+
+	ass.Equal([]int{6, 7, 9, 13, 15}, collect(rbtree.NewAscendRange(tree, rbtree.Int(6), rbtree.Int(15))))
+	ass.Equal([]int{15, 13, 9, 7, 6}, collect(rbtree.NewDescendRange(tree, rbtree.Int(15), rbtree.Int(6))))
+	ass.Equal([]int{6, 7, 9, 13}, collect(rbtree.NewOpenAscendRange(tree, rbtree.Int(5), rbtree.Int(14))))
+	ass.Equal([]int{13, 9, 7, 6}, collect(rbtree.NewOpenDescendRange(tree, rbtree.Int(14), rbtree.Int(5))))
+
+	var bounds = [][2]int{{-10, 3}, {19, 100}, {21, 100}, {-10, -5}, {-10, 100}, {10, 5}}
+	for _, b := range bounds {
+		from, to := rbtree.Int(b[0]), rbtree.Int(b[1])
+		ass.Equal(collect(rbtree.NewOpenAscendRange(reference, from, to)), collect(rbtree.NewOpenAscendRange(tree, from, to)), "%v", b)
+		ass.Equal(collect(rbtree.NewOpenDescendRange(reference, to, from)), collect(rbtree.NewOpenDescendRange(tree, to, from)), "%v", b)
+		ass.Equal(collect(rbtree.NewAscendRange(reference, from, to)), collect(rbtree.NewAscendRange(tree, from, to)), "%v", b)
+	}
+}
+
+func testIterator(t *testing.T, factory Factory) {
+	ass := assert.New(t)
+	tree := newTree(factory, testKeys)
+
+	it := rbtree.NewAscend(tree).Iterator()
+	var result []int
+	for it.Next() {
+		result = append(result, rbtree.GetInt(it.Current()))
+	}
+	ass.Equal(sortedTestKeys, result)
+	ass.False(it.Next())
+}
+
+func testTransactions(t *testing.T, factory Factory) {
+	ass := assert.New(t)
+	tree := newTree(factory, []int{1, 2, 3})
+
+	ass.ErrorIs(tree.Commit(), rbtree.ErrNoTransaction)
+	ass.ErrorIs(tree.Rollback(), rbtree.ErrNoTransaction)
+
+	tree.Begin()
+	tree.Insert(rbtree.Int(4))
+	tree.Delete(rbtree.Int(1))
+	ass.NoError(tree.Rollback())
+	ass.Equal([]int{1, 2, 3}, collect(rbtree.NewAscend(tree)))
+
+	tree.Begin()
+	tree.Insert(rbtree.Int(5))
+	tree.Begin()
+	tree.Delete(rbtree.Int(2))
+	ass.NoError(tree.Rollback())
+	ass.NoError(tree.Commit())
+	ass.Equal([]int{1, 2, 3, 5}, collect(rbtree.NewAscend(tree)))
+}
+
+func testSubscribe(t *testing.T, factory Factory) {
+	ass := assert.New(t)
+	tree := factory()
+	var kinds []rbtree.EventKind
+	unsubscribe := tree.Subscribe(func(e rbtree.Event) {
+		kinds = append(kinds, e.Kind)
+	}, rbtree.EventMutations)
+
+	tree.Insert(rbtree.Int(1))
+	tree.ReplaceOrInsert(rbtree.Int(1))
+	tree.Delete(rbtree.Int(1))
+	unsubscribe()
+	tree.Insert(rbtree.Int(2))
+
+	ass.Equal([]rbtree.EventKind{rbtree.EventInsert, rbtree.EventReplace, rbtree.EventDelete}, kinds)
+}
+
+func newTree(factory Factory, keys []int) rbtree.RbTree {
+	tree := factory()
+	for _, k := range keys {
+		tree.Insert(rbtree.Int(k))
+	}
+	return tree
+}
+
+func collect(e rbtree.Enumerable) []int {
+	var result []int
+	e.Foreach(func(c rbtree.Comparable) {
+		result = append(result, rbtree.GetInt(c))
+	})
+	return result
+}
+
+func reversed(keys []int) []int {
+	result := make([]int, len(keys))
+	for i, k := range keys {
+		result[len(keys)-1-i] = k
+	}
+	return result
+}
@@ -0,0 +1,20 @@
+package rbtreetest
+
+import (
+	"github.com/aegoroff/godatastruct/rbtree"
+	"testing"
+)
+
+func Test_RbTree_Conformance(t *testing.T) {
+	Run(t, rbtree.New)
+}
+
+func Test_RbTreeWithCodec_Conformance(t *testing.T) {
+	Run(t, func() rbtree.RbTree { return rbtree.NewWithCodec(rbtree.IntCodec) })
+}
+
+func Test_HashedTree_Conformance(t *testing.T) {
+	Run(t, func() rbtree.RbTree {
+		return rbtree.NewHashed(func(c rbtree.Comparable) uint64 { return uint64(rbtree.GetInt(c)) })
+	})
+}
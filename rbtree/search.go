@@ -34,19 +34,34 @@ func (tree *rbTree) Ceiling(value Comparable) (Comparable, bool) {
 }
 
 func (tree *rbTree) SearchAll(value Comparable) []Comparable {
-	var result []Comparable
-	n, ok := tree.SearchNode(value)
-	if ok {
+	first, _, count := tree.EqualRange(value)
+	if count == 0 {
+		return nil
+	}
+
+	result := make([]Comparable, 0, count)
+	for n := first; int64(len(result)) < count; n = n.Successor() {
 		result = append(result, n.key)
-		s := n.Successor()
-		for s.isNotNil() && s.key.Equal(value) {
-			result = append(result, s.key)
-			s = s.Successor()
-		}
 	}
 	return result
 }
 
+// EqualRange searches the first and the last nodes which keys are equal to value specified
+// and returns them together with the number of such nodes
+func (tree *rbTree) EqualRange(value Comparable) (*Node, *Node, int64) {
+	if tree.root.isNil() || value == nil {
+		return nil, nil, 0
+	}
+
+	first, lo := tree.root.lowerBound(value)
+	if first.isNil() || !first.key.Equal(value) {
+		return nil, nil, 0
+	}
+
+	last, hi := tree.root.upperBound(value)
+	return first, last, hi - lo + 1
+}
+
 // SearchNode searches *Node which key is equal to value specified
 func (tree *rbTree) SearchNode(value Comparable) (*Node, bool) {
 	if tree.root.isNil() {
@@ -59,6 +74,58 @@ func (tree *rbTree) SearchNode(value Comparable) (*Node, bool) {
 	return n, ok
 }
 
+// SearchFrom searches *Node which key is equal to value specified starting from the finger specified
+func (tree *rbTree) SearchFrom(finger *Node, value Comparable) (*Node, bool) {
+	if tree.root.isNil() || value == nil {
+		return nil, false
+	}
+	return tree.lca(finger, value).search(value)
+}
+
+// FloorFrom searches *Node with the greatest key lesser than or equal to value starting from the finger specified
+func (tree *rbTree) FloorFrom(finger *Node, value Comparable) (*Node, bool) {
+	if tree.root.isNil() || value == nil {
+		return nil, false
+	}
+	return tree.lca(finger, value).floor(value)
+}
+
+// CeilingFrom searches *Node with the smallest key larger than or equal to value starting from the finger specified
+func (tree *rbTree) CeilingFrom(finger *Node, value Comparable) (*Node, bool) {
+	if tree.root.isNil() || value == nil {
+		return nil, false
+	}
+	return tree.lca(finger, value).ceiling(value)
+}
+
+// lca walks up from the finger until it finds the node which subtree contains both finger and value
+// search position. Search, floor and ceiling started from this node give the same results as
+// started from the root. The finger must belong to the tree because its membership isn't checked:
+// walking up to the root to check it would cost O(log n) and make the finger useless
+func (tree *rbTree) lca(finger *Node, value Comparable) *Node {
+	if finger.isNil() || finger.parent == nil {
+		return tree.root
+	}
+
+	if value.Equal(finger.key) {
+		return finger
+	}
+
+	left := value.Less(finger.key)
+	x := finger
+	for y := x.parent; y.isNotNil(); x, y = y, y.parent {
+		// finger is in the right subtree of y and y <= value < finger
+		if left && x == y.right && !value.Less(y.key) {
+			return y
+		}
+		// finger is in the left subtree of y and finger < value <= y
+		if !left && x == y.left && !y.key.Less(value) {
+			return y
+		}
+	}
+	return tree.root
+}
+
 func (n *Node) search(value Comparable) (*Node, bool) {
 	if value == nil {
 		return nil, false
@@ -80,6 +147,42 @@ func (n *Node) search(value Comparable) (*Node, bool) {
 	return x, true
 }
 
+// lowerBound finds the first node which key is greater than or equal to value
+// and its rank within subtree
+func (n *Node) lowerBound(value Comparable) (*Node, int64) {
+	var result *Node
+	var rank int64
+	var passed int64
+	for x := n; x.isNotNil(); {
+		if x.key.Less(value) {
+			passed += x.left.size + 1
+			x = x.right
+		} else {
+			result = x
+			rank = passed + x.left.size + 1
+			x = x.left
+		}
+	}
+	return result, rank
+}
+
+// upperBound finds the last node which key is less than or equal to value
+// and its rank within subtree
+func (n *Node) upperBound(value Comparable) (*Node, int64) {
+	var result *Node
+	var rank int64
+	for x := n; x.isNotNil(); {
+		if value.Less(x.key) {
+			x = x.left
+		} else {
+			result = x
+			rank += x.left.size + 1
+			x = x.right
+		}
+	}
+	return result, rank
+}
+
 func (n *Node) floor(value Comparable) (*Node, bool) {
 	if value == nil {
 		return nil, false
@@ -132,6 +235,77 @@ func (n *Node) ceiling(value Comparable) (*Node, bool) {
 	return max, true
 }
 
+// FindFirst searches the first node for which monotone predicate specified becomes true
+func (tree *rbTree) FindFirst(pred KeyPredicate) (*Node, bool) {
+	if pred == nil {
+		return nil, false
+	}
+	n, _ := tree.root.findFirst(pred)
+	return n, n != nil
+}
+
+// FindLast searches the last node for which monotone predicate specified is true
+func (tree *rbTree) FindLast(pred KeyPredicate) (*Node, bool) {
+	if pred == nil {
+		return nil, false
+	}
+
+	var result *Node
+	for x := tree.root; x.isNotNil(); {
+		if pred(x.key) {
+			result = x
+			x = x.right
+		} else {
+			x = x.left
+		}
+	}
+	return result, result != nil
+}
+
+// PartitionPoint gets the number of nodes for which monotone predicate specified is false
+func (tree *rbTree) PartitionPoint(pred KeyPredicate) int64 {
+	if pred == nil {
+		return 0
+	}
+	_, before := tree.root.findFirst(pred)
+	return before
+}
+
+// CountPrefix gets the number of keys starting with the prefix specified in O(log n)
+// using subtree sizes. Tree keys must have the same type as the prefix
+func CountPrefix(t RbTree, prefix PrefixComparable) int64 {
+	first := t.PartitionPoint(func(c Comparable) bool {
+		return !c.Less(prefix) || c.(PrefixComparable).HasPrefix(prefix)
+	})
+	after := t.PartitionPoint(func(c Comparable) bool {
+		return !c.Less(prefix) && !c.(PrefixComparable).HasPrefix(prefix)
+	})
+	return after - first
+}
+
+// findFirst finds the first node for which predicate is true
+// and the number of nodes before it within subtree
+func (n *Node) findFirst(pred KeyPredicate) (*Node, int64) {
+	var result *Node
+	var passed int64
+	var before int64
+	for x := n; x.isNotNil(); {
+		if pred(x.key) {
+			result = x
+			before = passed + x.left.size
+			x = x.left
+		} else {
+			passed += x.left.size + 1
+			x = x.right
+		}
+	}
+
+	if result == nil {
+		return nil, passed
+	}
+	return result, before
+}
+
 // Minimum gets tree's min element
 func (tree *rbTree) Minimum() *Node {
 	if tree.root.isNil() {
@@ -212,6 +386,22 @@ func (n *Node) Predecessor() *Node {
 	return y
 }
 
+// Rank gets Node's position within the tree in ascending order
+// IMPORTANT: numeration starts from 1 not from 0
+func (n *Node) Rank() int64 {
+	if n.isNil() {
+		return 0
+	}
+
+	r := n.left.size + 1
+	for x, y := n, n.parent; y.isNotNil(); x, y = y, y.parent {
+		if x == y.right {
+			r += y.left.size + 1
+		}
+	}
+	return r
+}
+
 // OrderStatisticSelect gets i element from subtree
 // IMPORTANT: numeration starts from 1 not from 0
 func (tree *rbTree) OrderStatisticSelect(i int64) (*Node, bool) {
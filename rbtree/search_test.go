@@ -2,6 +2,7 @@ package rbtree
 
 import (
 	"github.com/stretchr/testify/assert"
+	"math/rand"
 	"testing"
 )
 
@@ -112,6 +113,105 @@ func Test_SearchAllIntTree_Success(t *testing.T) {
 	}
 }
 
+func Test_EqualRange_ResultAsExpected(t *testing.T) {
+	var tests = []struct {
+		name  string
+		input []int
+		value int
+		first int64
+		last  int64
+		count int64
+	}{
+		{"single", []int{1, 2, 3}, 2, 2, 2, 1},
+		{"duplicates in the middle", []int{1, 2, 2, 2, 3}, 2, 2, 4, 3},
+		{"duplicates at the beginning", []int{2, 2, 2, 3, 4}, 2, 1, 3, 3},
+		{"duplicates at the end", []int{1, 2, 4, 4}, 4, 3, 4, 2},
+		{"all duplicates", []int{5, 5, 5, 5, 5, 5, 5, 5}, 5, 1, 8, 8},
+		{"not found", []int{1, 2, 4, 4}, 3, 0, 0, 0},
+		{"empty", []int{}, 3, 0, 0, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			tree := newIntTree(test.input)
+
+			// Act
+			first, last, count := tree.EqualRange(Int(test.value))
+
+			// Assert
+			ass.Equal(test.count, count)
+			ass.Equal(test.first, first.Rank())
+			ass.Equal(test.last, last.Rank())
+			if count > 0 {
+				ass.Equal(test.value, GetInt(first.Key()))
+				ass.Equal(test.value, GetInt(last.Key()))
+			}
+		})
+	}
+}
+
+func Test_EqualRangeNil_NothingFound(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTestTree()
+
+	// Act
+	first, last, count := tree.EqualRange(nil)
+
+	// Assert
+	ass.Nil(first)
+	ass.Nil(last)
+	ass.Equal(int64(0), count)
+}
+
+func Test_EqualRangeManyDuplicates_CountAsExpected(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	for i := 0; i < 100; i++ {
+		tree.Insert(Int(i % 10))
+	}
+
+	for i := 0; i < 10; i++ {
+		// Act
+		first, last, count := tree.EqualRange(Int(i))
+
+		// Assert
+		ass.Equal(int64(10), count)
+		ass.Equal(int64(i*10+1), first.Rank())
+		ass.Equal(int64(i*10+10), last.Rank())
+	}
+}
+
+func Test_Rank_ValueAsExpected(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTestTree()
+
+	for i := int64(1); i <= tree.Len(); i++ {
+		n, _ := tree.OrderStatisticSelect(i)
+
+		// Act
+		r := n.Rank()
+
+		// Assert
+		ass.Equal(i, r)
+	}
+}
+
+func Test_RankOfNil_Zero(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	var n *Node
+
+	// Act
+	r := n.Rank()
+
+	// Assert
+	ass.Equal(int64(0), r)
+}
+
 func Test_SearchStringTree_Success(t *testing.T) {
 	// Arrange
 	ass := assert.New(t)
@@ -453,3 +553,191 @@ func Test_CeilingNullValye(t *testing.T) {
 	ass.False(ok)
 	ass.Nil(found)
 }
+
+func Test_FingerSearch_SameResultsAsFromRoot(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	r := rand.New(rand.NewSource(1000))
+	tree := New()
+	for i := 0; i < 300; i++ {
+		tree.Insert(Int(r.Intn(200) * 2))
+	}
+
+	for i := int64(1); i <= tree.Len(); i += 7 {
+		finger, _ := tree.OrderStatisticSelect(i)
+		for v := -1; v <= 401; v++ {
+			value := Int(v)
+
+			// Act
+			found, ok := tree.SearchFrom(finger, value)
+			floor, floorOk := tree.FloorFrom(finger, value)
+			ceiling, ceilingOk := tree.CeilingFrom(finger, value)
+
+			// Assert
+			expected, expectedOk := tree.Search(value)
+			ass.Equal(expectedOk, ok)
+			if ok {
+				ass.Equal(expected, found.Key())
+			}
+			expected, expectedOk = tree.Floor(value)
+			ass.Equal(expectedOk, floorOk)
+			ass.Equal(expected, floor.Key())
+			expected, expectedOk = tree.Ceiling(value)
+			ass.Equal(expectedOk, ceilingOk)
+			ass.Equal(expected, ceiling.Key())
+		}
+	}
+}
+
+func Test_FingerSearchInvalidFinger_SearchFromRoot(t *testing.T) {
+	deleted := newIntTestTree()
+	n, _ := deleted.SearchNode(Int(9))
+	deleted.DeleteNode(n)
+
+	var tests = []struct {
+		name   string
+		tree   RbTree
+		finger *Node
+	}{
+		{"nil finger", newIntTestTree(), nil},
+		{"deleted finger", deleted, n},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+
+			// Act
+			found, ok := test.tree.SearchFrom(test.finger, Int(13))
+			floor, _ := test.tree.FloorFrom(test.finger, Int(10))
+			ceiling, _ := test.tree.CeilingFrom(test.finger, Int(10))
+
+			// Assert
+			ass.True(ok)
+			ass.Equal(13, GetInt(found.Key()))
+			expected, _ := test.tree.Floor(Int(10))
+			ass.Equal(expected, floor.Key())
+			expected, _ = test.tree.Ceiling(Int(10))
+			ass.Equal(expected, ceiling.Key())
+		})
+	}
+}
+
+func Test_FingerSearchEmptyTreeOrNilValue_NothingFound(t *testing.T) {
+	tree := newIntTestTree()
+	finger, _ := tree.SearchNode(Int(9))
+
+	var tests = []struct {
+		name  string
+		tree  RbTree
+		value Comparable
+	}{
+		{"empty tree", New(), Int(9)},
+		{"nil value", tree, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+
+			// Act
+			_, ok1 := test.tree.SearchFrom(finger, test.value)
+			_, ok2 := test.tree.FloorFrom(finger, test.value)
+			_, ok3 := test.tree.CeilingFrom(finger, test.value)
+
+			// Assert
+			ass.False(ok1)
+			ass.False(ok2)
+			ass.False(ok3)
+		})
+	}
+}
+
+func Test_FindFirstAndLast_ResultAsExpected(t *testing.T) {
+	// []int{2, 3, 4, 6, 7, 9, 13, 15, 17, 18, 20}
+	var tests = []struct {
+		name      string
+		threshold int
+		first     int
+		firstOk   bool
+		last      int
+		lastOk    bool
+		point     int64
+	}{
+		{"below min", 1, 2, true, 0, false, 0},
+		{"min", 2, 2, true, 2, true, 0},
+		{"between", 10, 13, true, 9, true, 6},
+		{"exact", 13, 13, true, 13, true, 6},
+		{"max", 20, 20, true, 20, true, 10},
+		{"above max", 21, 0, false, 20, true, 11},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			tree := newIntTestTree()
+			ge := func(c Comparable) bool { return GetInt(c) >= test.threshold }
+			le := func(c Comparable) bool { return GetInt(c) <= test.threshold }
+
+			// Act
+			first, firstOk := tree.FindFirst(ge)
+			last, lastOk := tree.FindLast(le)
+			point := tree.PartitionPoint(ge)
+
+			// Assert
+			ass.Equal(test.firstOk, firstOk)
+			ass.Equal(test.lastOk, lastOk)
+			ass.Equal(test.point, point)
+			if firstOk {
+				ass.Equal(test.first, GetInt(first.Key()))
+				ass.Equal(point+1, first.Rank())
+			}
+			if lastOk {
+				ass.Equal(test.last, GetInt(last.Key()))
+			}
+		})
+	}
+}
+
+func Test_FindFirstWithDuplicates_FirstOfDuplicatesFound(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTree([]int{5, 1, 5, 5, 3, 5, 7, 5})
+
+	// Act
+	first, _ := tree.FindFirst(func(c Comparable) bool { return GetInt(c) >= 5 })
+	last, _ := tree.FindLast(func(c Comparable) bool { return GetInt(c) <= 5 })
+
+	// Assert
+	ass.Equal(int64(3), first.Rank())
+	ass.Equal(int64(7), last.Rank())
+}
+
+func Test_FindFirstAndLastEmptyTreeOrNilPredicate_NothingFound(t *testing.T) {
+	var tests = []struct {
+		name string
+		tree RbTree
+		pred KeyPredicate
+	}{
+		{"empty tree", New(), func(Comparable) bool { return true }},
+		{"nil predicate", newIntTestTree(), nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+
+			// Act
+			first, firstOk := test.tree.FindFirst(test.pred)
+			last, lastOk := test.tree.FindLast(test.pred)
+			point := test.tree.PartitionPoint(test.pred)
+
+			// Assert
+			ass.False(firstOk)
+			ass.Nil(first)
+			ass.False(lastOk)
+			ass.Nil(last)
+			ass.Equal(int64(0), point)
+		})
+	}
+}
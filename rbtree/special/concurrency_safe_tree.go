@@ -1,10 +1,16 @@
 package special
 
 import (
+	"encoding"
+	"encoding/json"
+	"errors"
 	"github.com/aegoroff/godatastruct/rbtree"
 	"sync"
 )
 
+// ErrEncodingNotSupported is returned when underlying tree doesn't support encoding requested
+var ErrEncodingNotSupported = errors.New("underlying tree doesn't support encoding")
+
 type concurrencySafeTree struct {
 	// tree contains underlying Red-black search binary tree
 	tree rbtree.RbTree
@@ -17,6 +23,46 @@ func (t *concurrencySafeTree) Root() *rbtree.Node {
 	return t.tree.Root()
 }
 
+// Stats calculates underlying tree statistics under the read lock
+func (t *concurrencySafeTree) Stats() rbtree.TreeStats {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Stats()
+}
+
+// Subscribe registers handler on the underlying tree under the write lock.
+// Handlers are called while the lock is held so they must not call the tree
+func (t *concurrencySafeTree) Subscribe(handler rbtree.EventHandler, events rbtree.EventKind) func() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	unsubscribe := t.tree.Subscribe(handler, events)
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		unsubscribe()
+	}
+}
+
+// Begin starts transaction on the underlying tree under the write lock.
+// Transaction isn't isolated so modifications made by other goroutines are journaled too
+func (t *concurrencySafeTree) Begin() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tree.Begin()
+}
+
+func (t *concurrencySafeTree) Commit() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tree.Commit()
+}
+
+func (t *concurrencySafeTree) Rollback() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tree.Rollback()
+}
+
 func (t *concurrencySafeTree) Len() int64 {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -47,6 +93,18 @@ func (t *concurrencySafeTree) DeleteAll(c rbtree.Comparable) bool {
 	return t.tree.DeleteAll(c)
 }
 
+func (t *concurrencySafeTree) DeleteNode(n *rbtree.Node) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tree.DeleteNode(n)
+}
+
+func (t *concurrencySafeTree) UpdateKey(n *rbtree.Node, key rbtree.Comparable) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tree.UpdateKey(n, key)
+}
+
 func (t *concurrencySafeTree) Search(value rbtree.Comparable) (rbtree.Comparable, bool) {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -71,12 +129,54 @@ func (t *concurrencySafeTree) SearchAll(value rbtree.Comparable) []rbtree.Compar
 	return t.tree.SearchAll(value)
 }
 
+func (t *concurrencySafeTree) EqualRange(value rbtree.Comparable) (*rbtree.Node, *rbtree.Node, int64) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.EqualRange(value)
+}
+
 func (t *concurrencySafeTree) SearchNode(value rbtree.Comparable) (*rbtree.Node, bool) {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 	return t.tree.SearchNode(value)
 }
 
+func (t *concurrencySafeTree) SearchFrom(finger *rbtree.Node, value rbtree.Comparable) (*rbtree.Node, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.SearchFrom(finger, value)
+}
+
+func (t *concurrencySafeTree) FloorFrom(finger *rbtree.Node, value rbtree.Comparable) (*rbtree.Node, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.FloorFrom(finger, value)
+}
+
+func (t *concurrencySafeTree) CeilingFrom(finger *rbtree.Node, value rbtree.Comparable) (*rbtree.Node, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.CeilingFrom(finger, value)
+}
+
+func (t *concurrencySafeTree) FindFirst(pred rbtree.KeyPredicate) (*rbtree.Node, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.FindFirst(pred)
+}
+
+func (t *concurrencySafeTree) FindLast(pred rbtree.KeyPredicate) (*rbtree.Node, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.FindLast(pred)
+}
+
+func (t *concurrencySafeTree) PartitionPoint(pred rbtree.KeyPredicate) int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.PartitionPoint(pred)
+}
+
 func (t *concurrencySafeTree) Minimum() *rbtree.Node {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -96,6 +196,74 @@ func (t *concurrencySafeTree) OrderStatisticSelect(i int64) (*rbtree.Node, bool)
 	return n, ok
 }
 
+func (t *concurrencySafeTree) Quantile(q float64) (rbtree.Comparable, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Quantile(q)
+}
+
+func (t *concurrencySafeTree) Median() (rbtree.Comparable, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Median()
+}
+
+func (t *concurrencySafeTree) Percentiles(qs ...float64) ([]rbtree.Comparable, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.Percentiles(qs...)
+}
+
+func (t *concurrencySafeTree) InterpolatedQuantile(q float64) (float64, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.InterpolatedQuantile(q)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface encoding underlying tree under the read lock
+func (t *concurrencySafeTree) MarshalBinary() ([]byte, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	m, ok := t.tree.(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, ErrEncodingNotSupported
+	}
+	return m.MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface decoding underlying tree under the write lock
+func (t *concurrencySafeTree) UnmarshalBinary(data []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	u, ok := t.tree.(encoding.BinaryUnmarshaler)
+	if !ok {
+		return ErrEncodingNotSupported
+	}
+	return u.UnmarshalBinary(data)
+}
+
+// MarshalJSON implements json.Marshaler interface encoding underlying tree under the read lock
+func (t *concurrencySafeTree) MarshalJSON() ([]byte, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	m, ok := t.tree.(json.Marshaler)
+	if !ok {
+		return nil, ErrEncodingNotSupported
+	}
+	return m.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface decoding underlying tree under the write lock
+func (t *concurrencySafeTree) UnmarshalJSON(data []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	u, ok := t.tree.(json.Unmarshaler)
+	if !ok {
+		return ErrEncodingNotSupported
+	}
+	return u.UnmarshalJSON(data)
+}
+
 // NewConcurrencySafeTree creates new concurrency safe tree that can be used in concurrency scenarios
 func NewConcurrencySafeTree() rbtree.RbTree {
 	return WrapToConcurrencySafe(rbtree.New())
@@ -1,7 +1,10 @@
 package special
 
 import (
+	"encoding"
+	"encoding/json"
 	"github.com/aegoroff/godatastruct/rbtree"
+	"github.com/aegoroff/godatastruct/rbtree/rbtreetest"
 	"github.com/stretchr/testify/assert"
 	"sync"
 	"testing"
@@ -113,6 +116,62 @@ func Test_ConcurrencySafeTree_DeleteNodeTest(t *testing.T) {
 	ass.Equal(int64(0), tree.Len())
 }
 
+func Test_ConcurrencySafeTree_DeleteNodeByHandleTest(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	var wg sync.WaitGroup
+
+	const nodesCount = 200
+	tree := NewConcurrencySafeTree()
+
+	for i := 1; i <= nodesCount; i++ {
+		tree.Insert(rbtree.Int(i))
+	}
+
+	// Act
+	for i := 1; i <= nodesCount; i++ {
+		n, _ := tree.SearchNode(rbtree.Int(i))
+		wg.Add(1)
+		go func(n *rbtree.Node) {
+			defer wg.Done()
+			tree.DeleteNode(n)
+		}(n)
+	}
+	wg.Wait()
+
+	// Assert
+	ass.Equal(int64(0), tree.Len())
+}
+
+func Test_ConcurrencySafeTree_UpdateKeyTest(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	var wg sync.WaitGroup
+
+	const nodesCount = 200
+	tree := NewConcurrencySafeTree()
+
+	for i := 1; i <= nodesCount; i++ {
+		tree.Insert(rbtree.Int(i))
+	}
+
+	// Act
+	for i := 1; i <= nodesCount; i++ {
+		n, _ := tree.SearchNode(rbtree.Int(i))
+		wg.Add(1)
+		go func(n *rbtree.Node, ix int) {
+			defer wg.Done()
+			tree.UpdateKey(n, rbtree.Int(nodesCount+ix))
+		}(n, i)
+	}
+	wg.Wait()
+
+	// Assert
+	ass.Equal(int64(nodesCount), tree.Len())
+	ass.Equal(nodesCount+1, rbtree.GetInt(tree.Minimum().Key()))
+	ass.Equal(2*nodesCount, rbtree.GetInt(tree.Maximum().Key()))
+}
+
 func Test_ConcurrencySafeTree_DeleteAllNodesTest(t *testing.T) {
 	// Arrange
 	ass := assert.New(t)
@@ -444,6 +503,85 @@ func Test_ConcurrencySafeTree_ConcurrentModificationAndOrderStatisticSelectTest(
 	ass.True(res)
 }
 
+func Test_ConcurrencySafeTree_ConcurrentModificationAndQuantilesTest(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	var wg sync.WaitGroup
+
+	const nodesCount = 200
+	tree := NewConcurrencySafeTree()
+	readResultsChan := make(chan bool, nodesCount/2)
+
+	for i := 1; i <= nodesCount; i++ {
+		tree.Insert(rbtree.Int(i))
+	}
+
+	// Act
+	for i := 1; i <= nodesCount/2; i++ {
+		wg.Add(1)
+		go func(ix int) {
+			defer wg.Done()
+			tree.Delete(rbtree.Int(ix))
+		}(i)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ps, err := tree.Percentiles(0, 0.5, 1)
+			_, ierr := tree.InterpolatedQuantile(0.5)
+			m, merr := tree.Median()
+			readResultsChan <- err == nil && ierr == nil && merr == nil &&
+				rbtree.GetInt(ps[2]) == nodesCount && rbtree.GetInt(m) > nodesCount/4
+		}()
+	}
+	wg.Wait()
+	close(readResultsChan)
+
+	// Assert
+	ass.Equal(int64(nodesCount/2), tree.Len())
+	for ok := range readResultsChan {
+		ass.True(ok)
+	}
+}
+
+func Test_ConcurrencySafeTree_ConcurrentModificationAndStatsTest(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	var wg sync.WaitGroup
+
+	const nodesCount = 200
+	tree := NewConcurrencySafeTree()
+	readResultsChan := make(chan bool, nodesCount)
+
+	// Act
+	for i := 1; i <= nodesCount; i++ {
+		wg.Add(1)
+		go func(ix int) {
+			defer wg.Done()
+			tree.Insert(rbtree.Int(ix))
+		}(i)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stats := tree.Stats()
+			var total int64
+			for _, c := range stats.DepthHistogram {
+				total += c
+			}
+			readResultsChan <- total == stats.Count
+		}()
+	}
+	wg.Wait()
+	close(readResultsChan)
+
+	// Assert
+	ass.Equal(int64(nodesCount), tree.Stats().Count)
+	for ok := range readResultsChan {
+		ass.True(ok)
+	}
+}
+
 func Test_ConcurrencySafeTree_Foreach(t *testing.T) {
 	tree := NewConcurrencySafeTree()
 	tree.Insert(rbtree.Int(6))
@@ -477,3 +615,107 @@ func Test_ConcurrencySafeTree_Foreach(t *testing.T) {
 		})
 	}
 }
+
+func Test_ConcurrencySafeTree_MarshalUnmarshalBinary(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	src := NewConcurrencySafeTree()
+	dst := NewConcurrencySafeTree()
+	for i := 1; i <= 10; i++ {
+		src.Insert(rbtree.Int(i))
+	}
+
+	// Act
+	data, err := src.(encoding.BinaryMarshaler).MarshalBinary()
+	ass.NoError(err)
+	err = dst.(encoding.BinaryUnmarshaler).UnmarshalBinary(data)
+
+	// Assert
+	ass.NoError(err)
+	ass.Equal(int64(10), dst.Len())
+	ass.Equal(10, rbtree.GetInt(dst.Maximum().Key()))
+}
+
+func Test_ConcurrencySafeTreeOverFixedTree_EncodingNotSupported(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := WrapToConcurrencySafe(NewMaxTree(3))
+
+	// Act
+	_, err1 := tree.(encoding.BinaryMarshaler).MarshalBinary()
+	err2 := tree.(encoding.BinaryUnmarshaler).UnmarshalBinary([]byte{})
+
+	// Assert
+	ass.ErrorIs(err1, ErrEncodingNotSupported)
+	ass.ErrorIs(err2, ErrEncodingNotSupported)
+}
+
+func Test_ConcurrencySafeTree_MarshalUnmarshalJSON(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	src := NewConcurrencySafeTree()
+	dst := NewConcurrencySafeTree()
+	for i := 3; i >= 1; i-- {
+		src.Insert(rbtree.Int(i))
+	}
+
+	// Act
+	data, err := json.Marshal(src)
+	ass.NoError(err)
+	err = json.Unmarshal(data, dst)
+
+	// Assert
+	ass.NoError(err)
+	ass.Equal("[1,2,3]", string(data))
+	ass.Equal(int64(3), dst.Len())
+	_, err = json.Marshal(WrapToConcurrencySafe(NewMinTree(1)))
+	ass.ErrorIs(err, ErrEncodingNotSupported)
+}
+
+func Test_ConcurrencySafeTree_Subscribe(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := NewConcurrencySafeTree()
+	var inserted int64
+	unsubscribe := tree.Subscribe(func(e rbtree.Event) { inserted++ }, rbtree.EventInsert)
+	var wg sync.WaitGroup
+
+	// Act
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(ix int) {
+			defer wg.Done()
+			tree.Insert(rbtree.Int(ix))
+		}(i)
+	}
+	wg.Wait()
+	unsubscribe()
+	tree.Insert(rbtree.Int(200))
+
+	// Assert
+	ass.Equal(int64(100), inserted)
+}
+
+func Test_ConcurrencySafeTree_Rollback(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := NewConcurrencySafeTree()
+	tree.Insert(rbtree.Int(1))
+
+	// Act
+	tree.Begin()
+	tree.Insert(rbtree.Int(2))
+	tree.Delete(rbtree.Int(1))
+	err := tree.Rollback()
+
+	// Assert
+	ass.NoError(err)
+	ass.Equal(int64(1), tree.Len())
+	_, ok := tree.Search(rbtree.Int(1))
+	ass.True(ok)
+	ass.ErrorIs(tree.Commit(), rbtree.ErrNoTransaction)
+}
+
+func Test_ConcurrencySafeTree_Conformance(t *testing.T) {
+	rbtreetest.Run(t, NewConcurrencySafeTree)
+}
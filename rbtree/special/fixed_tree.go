@@ -14,6 +14,26 @@ func (t *maxTree) Root() *rbtree.Node {
 	return t.tree.Root()
 }
 
+func (t *maxTree) Stats() rbtree.TreeStats {
+	return t.tree.Stats()
+}
+
+func (t *maxTree) Subscribe(handler rbtree.EventHandler, events rbtree.EventKind) func() {
+	return t.tree.Subscribe(handler, events)
+}
+
+func (t *maxTree) Begin() {
+	t.tree.Begin()
+}
+
+func (t *maxTree) Commit() error {
+	return t.tree.Commit()
+}
+
+func (t *maxTree) Rollback() error {
+	return t.tree.Rollback()
+}
+
 func (t *maxTree) Len() int64 {
 	return t.tree.Len()
 }
@@ -51,6 +71,14 @@ func (t *maxTree) DeleteAll(c rbtree.Comparable) bool {
 	return t.tree.DeleteAll(c)
 }
 
+func (t *maxTree) DeleteNode(n *rbtree.Node) bool {
+	return t.tree.DeleteNode(n)
+}
+
+func (t *maxTree) UpdateKey(n *rbtree.Node, key rbtree.Comparable) bool {
+	return t.tree.UpdateKey(n, key)
+}
+
 func (t *maxTree) Search(value rbtree.Comparable) (rbtree.Comparable, bool) {
 	return t.tree.Search(value)
 }
@@ -67,10 +95,38 @@ func (t *maxTree) SearchAll(value rbtree.Comparable) []rbtree.Comparable {
 	return t.tree.SearchAll(value)
 }
 
+func (t *maxTree) EqualRange(value rbtree.Comparable) (*rbtree.Node, *rbtree.Node, int64) {
+	return t.tree.EqualRange(value)
+}
+
 func (t *maxTree) SearchNode(value rbtree.Comparable) (*rbtree.Node, bool) {
 	return t.tree.SearchNode(value)
 }
 
+func (t *maxTree) SearchFrom(finger *rbtree.Node, value rbtree.Comparable) (*rbtree.Node, bool) {
+	return t.tree.SearchFrom(finger, value)
+}
+
+func (t *maxTree) FloorFrom(finger *rbtree.Node, value rbtree.Comparable) (*rbtree.Node, bool) {
+	return t.tree.FloorFrom(finger, value)
+}
+
+func (t *maxTree) CeilingFrom(finger *rbtree.Node, value rbtree.Comparable) (*rbtree.Node, bool) {
+	return t.tree.CeilingFrom(finger, value)
+}
+
+func (t *maxTree) FindFirst(pred rbtree.KeyPredicate) (*rbtree.Node, bool) {
+	return t.tree.FindFirst(pred)
+}
+
+func (t *maxTree) FindLast(pred rbtree.KeyPredicate) (*rbtree.Node, bool) {
+	return t.tree.FindLast(pred)
+}
+
+func (t *maxTree) PartitionPoint(pred rbtree.KeyPredicate) int64 {
+	return t.tree.PartitionPoint(pred)
+}
+
 func (t *maxTree) Minimum() *rbtree.Node {
 	return t.tree.Minimum()
 }
@@ -83,6 +139,22 @@ func (t *maxTree) OrderStatisticSelect(i int64) (*rbtree.Node, bool) {
 	return t.tree.OrderStatisticSelect(i)
 }
 
+func (t *maxTree) Quantile(q float64) (rbtree.Comparable, error) {
+	return t.tree.Quantile(q)
+}
+
+func (t *maxTree) Median() (rbtree.Comparable, error) {
+	return t.tree.Median()
+}
+
+func (t *maxTree) Percentiles(qs ...float64) ([]rbtree.Comparable, error) {
+	return t.tree.Percentiles(qs...)
+}
+
+func (t *maxTree) InterpolatedQuantile(q float64) (float64, error) {
+	return t.tree.InterpolatedQuantile(q)
+}
+
 // minTree represents Red-black search binary tree
 // that stores only limited size of min possible values
 type minTree struct {
@@ -95,6 +167,26 @@ func (t *minTree) Root() *rbtree.Node {
 	return t.tree.Root()
 }
 
+func (t *minTree) Stats() rbtree.TreeStats {
+	return t.tree.Stats()
+}
+
+func (t *minTree) Subscribe(handler rbtree.EventHandler, events rbtree.EventKind) func() {
+	return t.tree.Subscribe(handler, events)
+}
+
+func (t *minTree) Begin() {
+	t.tree.Begin()
+}
+
+func (t *minTree) Commit() error {
+	return t.tree.Commit()
+}
+
+func (t *minTree) Rollback() error {
+	return t.tree.Rollback()
+}
+
 func (t *minTree) Len() int64 {
 	return t.tree.Len()
 }
@@ -132,6 +224,14 @@ func (t *minTree) DeleteAll(c rbtree.Comparable) bool {
 	return t.tree.DeleteAll(c)
 }
 
+func (t *minTree) DeleteNode(n *rbtree.Node) bool {
+	return t.tree.DeleteNode(n)
+}
+
+func (t *minTree) UpdateKey(n *rbtree.Node, key rbtree.Comparable) bool {
+	return t.tree.UpdateKey(n, key)
+}
+
 func (t *minTree) Search(value rbtree.Comparable) (rbtree.Comparable, bool) {
 	return t.tree.Search(value)
 }
@@ -148,10 +248,38 @@ func (t *minTree) SearchAll(value rbtree.Comparable) []rbtree.Comparable {
 	return t.tree.SearchAll(value)
 }
 
+func (t *minTree) EqualRange(value rbtree.Comparable) (*rbtree.Node, *rbtree.Node, int64) {
+	return t.tree.EqualRange(value)
+}
+
 func (t *minTree) SearchNode(value rbtree.Comparable) (*rbtree.Node, bool) {
 	return t.tree.SearchNode(value)
 }
 
+func (t *minTree) SearchFrom(finger *rbtree.Node, value rbtree.Comparable) (*rbtree.Node, bool) {
+	return t.tree.SearchFrom(finger, value)
+}
+
+func (t *minTree) FloorFrom(finger *rbtree.Node, value rbtree.Comparable) (*rbtree.Node, bool) {
+	return t.tree.FloorFrom(finger, value)
+}
+
+func (t *minTree) CeilingFrom(finger *rbtree.Node, value rbtree.Comparable) (*rbtree.Node, bool) {
+	return t.tree.CeilingFrom(finger, value)
+}
+
+func (t *minTree) FindFirst(pred rbtree.KeyPredicate) (*rbtree.Node, bool) {
+	return t.tree.FindFirst(pred)
+}
+
+func (t *minTree) FindLast(pred rbtree.KeyPredicate) (*rbtree.Node, bool) {
+	return t.tree.FindLast(pred)
+}
+
+func (t *minTree) PartitionPoint(pred rbtree.KeyPredicate) int64 {
+	return t.tree.PartitionPoint(pred)
+}
+
 func (t *minTree) Minimum() *rbtree.Node {
 	return t.tree.Minimum()
 }
@@ -164,6 +292,22 @@ func (t *minTree) OrderStatisticSelect(i int64) (*rbtree.Node, bool) {
 	return t.tree.OrderStatisticSelect(i)
 }
 
+func (t *minTree) Quantile(q float64) (rbtree.Comparable, error) {
+	return t.tree.Quantile(q)
+}
+
+func (t *minTree) Median() (rbtree.Comparable, error) {
+	return t.tree.Median()
+}
+
+func (t *minTree) Percentiles(qs ...float64) ([]rbtree.Comparable, error) {
+	return t.tree.Percentiles(qs...)
+}
+
+func (t *minTree) InterpolatedQuantile(q float64) (float64, error) {
+	return t.tree.InterpolatedQuantile(q)
+}
+
 // NewMaxTree creates new fixed size tree that stores <sz> max values
 func NewMaxTree(sz int64) rbtree.RbTree {
 	return &maxTree{
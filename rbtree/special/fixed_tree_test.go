@@ -2,6 +2,7 @@ package special
 
 import (
 	"github.com/aegoroff/godatastruct/rbtree"
+	"github.com/aegoroff/godatastruct/rbtree/rbtreetest"
 	"github.com/stretchr/testify/assert"
 	"math/rand"
 	"testing"
@@ -200,6 +201,49 @@ func Test_OrderStatisticSelect_ValueAsExpected(t *testing.T) {
 	}
 }
 
+func Test_Quantiles_ValueAsExpected(t *testing.T) {
+	// Arrange
+	minTree := NewMinTree(5)
+	maxTree := NewMaxTree(5)
+
+	for i := 1; i <= 10; i++ {
+		minTree.Insert(rbtree.Int(i))
+		maxTree.Insert(rbtree.Int(i))
+	}
+
+	var tests = []struct {
+		name         string
+		tree         rbtree.RbTree
+		median       int
+		percentiles  []rbtree.Comparable
+		interpolated float64
+	}{
+		{"Min tree", minTree, 3, []rbtree.Comparable{rbtree.Int(1), rbtree.Int(5)}, 1.8},
+		{"Max tree", maxTree, 8, []rbtree.Comparable{rbtree.Int(6), rbtree.Int(10)}, 6.8},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ass := assert.New(t)
+
+			// Act
+			median, err1 := test.tree.Median()
+			q, err2 := test.tree.Quantile(0.5)
+			percentiles, err3 := test.tree.Percentiles(0, 1)
+			interpolated, err4 := test.tree.InterpolatedQuantile(0.2)
+
+			// Assert
+			ass.NoError(err1)
+			ass.NoError(err2)
+			ass.NoError(err3)
+			ass.NoError(err4)
+			ass.Equal(test.median, rbtree.GetInt(median))
+			ass.Equal(median, q)
+			ass.Equal(test.percentiles, percentiles)
+			ass.InDelta(test.interpolated, interpolated, 1e-9)
+		})
+	}
+}
+
 func Test_SearchIntTree_Success(t *testing.T) {
 	// Arrange
 	minTree := NewMinTree(3)
@@ -337,6 +381,40 @@ func Test_SearchAllIntTree_Success(t *testing.T) {
 	}
 }
 
+func Test_EqualRangeIntTree_Success(t *testing.T) {
+	// Arrange
+	minTree := NewMinTree(3)
+	maxTree := NewMaxTree(3)
+
+	for i := 1; i <= 10; i++ {
+		minTree.Insert(rbtree.Int(i))
+		maxTree.Insert(rbtree.Int(i))
+	}
+
+	var tests = []struct {
+		name     string
+		tree     rbtree.RbTree
+		expected int
+	}{
+		{"Min tree", minTree, 1},
+		{"Max tree", maxTree, 8},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ass := assert.New(t)
+			v := rbtree.Int(test.expected)
+
+			// Act
+			first, last, count := test.tree.EqualRange(v)
+
+			// Assert
+			ass.Equal(int64(1), count)
+			ass.Equal(first, last)
+			ass.Equal(test.expected, rbtree.GetInt(first.Key()))
+		})
+	}
+}
+
 func Test_SearchNodeIntTree_Success(t *testing.T) {
 	// Arrange
 	minTree := NewMinTree(3)
@@ -406,6 +484,80 @@ func Test_DeleteNode_Success(t *testing.T) {
 	}
 }
 
+func Test_DeleteNodeByHandle_Success(t *testing.T) {
+	// Arrange
+	minTree := NewMinTree(3)
+	maxTree := NewMaxTree(3)
+
+	for i := 1; i <= 10; i++ {
+		minTree.Insert(rbtree.Int(i))
+		maxTree.Insert(rbtree.Int(i))
+	}
+
+	var tests = []struct {
+		name     string
+		tree     rbtree.RbTree
+		expected int
+	}{
+		{"Min tree", minTree, 1},
+		{"Max tree", maxTree, 8},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ass := assert.New(t)
+			v := rbtree.Int(test.expected)
+			n, _ := test.tree.SearchNode(v)
+
+			// Act
+			ok := test.tree.DeleteNode(n)
+
+			// Assert
+			ass.True(ok)
+			ass.Equal(int64(2), test.tree.Len())
+			_, ok = test.tree.Search(v)
+			ass.False(ok)
+		})
+	}
+}
+
+func Test_UpdateKey_Success(t *testing.T) {
+	// Arrange
+	minTree := NewMinTree(3)
+	maxTree := NewMaxTree(3)
+
+	for i := 1; i <= 10; i++ {
+		minTree.Insert(rbtree.Int(i))
+		maxTree.Insert(rbtree.Int(i))
+	}
+
+	var tests = []struct {
+		name     string
+		tree     rbtree.RbTree
+		key      int
+		expected []int
+	}{
+		{"Min tree", minTree, 1, []int{2, 3, 11}},
+		{"Max tree", maxTree, 8, []int{9, 10, 11}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ass := assert.New(t)
+			n, _ := test.tree.SearchNode(rbtree.Int(test.key))
+			result := make([]int, 0)
+
+			// Act
+			ok := test.tree.UpdateKey(n, rbtree.Int(11))
+
+			// Assert
+			ass.True(ok)
+			rbtree.NewAscend(test.tree).Foreach(func(c rbtree.Comparable) {
+				result = append(result, rbtree.GetInt(c))
+			})
+			ass.Equal(test.expected, result)
+		})
+	}
+}
+
 func Test_ReplaceOrInsertMinTreeDuplicateLessThenPrevNodes_PrevNodesDeletedLenLessThenMinLen(t *testing.T) {
 	// Arrange
 	ass := assert.New(t)
@@ -636,3 +788,30 @@ func randomString(n int) string {
 	}
 	return string(s)
 }
+
+func Test_MaxTree_Subscribe_EvictionReported(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := NewMaxTree(2)
+	var deleted []rbtree.Comparable
+	tree.Subscribe(func(e rbtree.Event) { deleted = append(deleted, e.Key) }, rbtree.EventDelete)
+
+	// Act
+	tree.Insert(rbtree.Int(1))
+	tree.Insert(rbtree.Int(2))
+	tree.Insert(rbtree.Int(3))
+	tree.Insert(rbtree.Int(0))
+
+	// Assert
+	ass.Equal([]rbtree.Comparable{rbtree.Int(1)}, deleted)
+}
+
+func Test_FixedTrees_CapacityNotReached_Conformance(t *testing.T) {
+	// Fixed trees behave like usual tree until their size limit is reached
+	t.Run("MaxTree", func(t *testing.T) {
+		rbtreetest.Run(t, func() rbtree.RbTree { return NewMaxTree(1 << 20) })
+	})
+	t.Run("MinTree", func(t *testing.T) {
+		rbtreetest.Run(t, func() rbtree.RbTree { return NewMinTree(1 << 20) })
+	})
+}
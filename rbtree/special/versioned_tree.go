@@ -0,0 +1,212 @@
+package special
+
+import (
+	"errors"
+	"github.com/aegoroff/godatastruct/rbtree"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrVersionNotRetained is returned when version requested was garbage collected or never existed
+	ErrVersionNotRetained = errors.New("version is not retained")
+
+	// ErrReadOnlyView is returned when transaction methods are called on ReadView
+	// and used as panic value when ReadView modification methods are called
+	ErrReadOnlyView = errors.New("read view cannot be modified")
+)
+
+// ReadView represents read only tree content as of some committed version.
+// All search and iteration methods are available while modification methods
+// (Insert, ReplaceOrInsert, Delete, DeleteAll, DeleteNode and UpdateKey) panic with ErrReadOnlyView
+// so writes to a snapshot cannot be silently lost.
+// View must be closed when no longer needed so its version can be garbage collected.
+// View must not be used after Close
+type ReadView interface {
+	rbtree.RbTree
+
+	// Version gets the version the view reads
+	Version() uint64
+
+	// Close releases the view. Calling Close several times is safe
+	Close()
+}
+
+// VersionedTree is multi-version Red-black tree. Every committed write batch produces new version
+// and readers can open read views at any retained version while writers continue.
+// The latest version and versions held by any view are retained, others are garbage collected.
+//
+// IMPORTANT: versions don't share structure. A write batch while any view is open on the latest version
+// copies the whole tree i.e. costs O(n) time and memory in addition to the batch itself. So with
+// long living readers of the latest version (the common readers plus writers case) every batch costs O(n).
+// Only when no view holds the latest version the tree is modified in place and the batch costs
+// O(log n) per modification. Batch writes and close views as soon as possible to amortize copying
+type VersionedTree struct {
+	mu       sync.Mutex
+	versions map[uint64]*version
+	latest   uint64
+}
+
+type version struct {
+	tree rbtree.RbTree
+	refs int
+}
+
+type readView struct {
+	rbtree.RbTree
+	version uint64
+	owner   *VersionedTree
+	once    sync.Once
+}
+
+// NewVersionedTree creates new multi-version tree. Its initial version zero is empty
+func NewVersionedTree() *VersionedTree {
+	return &VersionedTree{
+		versions: map[uint64]*version{0: {tree: rbtree.New()}},
+	}
+}
+
+// Write applies batch to the latest version content. If batch returns nil new version is
+// committed and its number returned. Otherwise all batch modifications are discarded
+// and the error is returned. The tree passed to batch must not be used after batch returned.
+// Writes are serialized and views opening waits until write completed
+func (v *VersionedTree) Write(batch func(t rbtree.RbTree) error) (uint64, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	current := v.versions[v.latest]
+	if current.refs > 0 {
+		work := rbtree.Clone(current.tree)
+		if err := batch(work); err != nil {
+			return v.latest, err
+		}
+		return v.commit(work), nil
+	}
+
+	work := current.tree
+	work.Begin()
+	if err := batch(work); err != nil {
+		_ = work.Rollback()
+		return v.latest, err
+	}
+	_ = work.Commit()
+	delete(v.versions, v.latest)
+	return v.commit(work), nil
+}
+
+// Version gets the latest committed version number
+func (v *VersionedTree) Version() uint64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.latest
+}
+
+// Versions gets all retained version numbers in ascending order
+func (v *VersionedTree) Versions() []uint64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	result := make([]uint64, 0, len(v.versions))
+	for n := range v.versions {
+		result = append(result, n)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// View opens read view at the latest version
+func (v *VersionedTree) View() ReadView {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.open(v.latest)
+}
+
+// ViewAt opens read view at version specified.
+// ErrVersionNotRetained is returned if the version isn't retained
+func (v *VersionedTree) ViewAt(n uint64) (ReadView, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if _, ok := v.versions[n]; !ok {
+		return nil, ErrVersionNotRetained
+	}
+	return v.open(n), nil
+}
+
+func (v *VersionedTree) commit(t rbtree.RbTree) uint64 {
+	v.latest++
+	v.versions[v.latest] = &version{tree: t}
+	return v.latest
+}
+
+func (v *VersionedTree) open(n uint64) ReadView {
+	ver := v.versions[n]
+	ver.refs++
+	return &readView{RbTree: ver.tree, version: n, owner: v}
+}
+
+func (v *VersionedTree) release(n uint64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	ver := v.versions[n]
+	ver.refs--
+	if ver.refs == 0 && n != v.latest {
+		delete(v.versions, n)
+	}
+}
+
+func (r *readView) Version() uint64 {
+	return r.version
+}
+
+func (r *readView) Close() {
+	r.once.Do(func() {
+		r.owner.release(r.version)
+	})
+}
+
+// Insert panics with ErrReadOnlyView because view is read only
+func (r *readView) Insert(rbtree.Comparable) {
+	panic(ErrReadOnlyView)
+}
+
+// ReplaceOrInsert panics with ErrReadOnlyView because view is read only
+func (r *readView) ReplaceOrInsert(rbtree.Comparable) rbtree.Comparable {
+	panic(ErrReadOnlyView)
+}
+
+// Delete panics with ErrReadOnlyView because view is read only
+func (r *readView) Delete(rbtree.Comparable) bool {
+	panic(ErrReadOnlyView)
+}
+
+// DeleteAll panics with ErrReadOnlyView because view is read only
+func (r *readView) DeleteAll(rbtree.Comparable) bool {
+	panic(ErrReadOnlyView)
+}
+
+// DeleteNode panics with ErrReadOnlyView because view is read only
+func (r *readView) DeleteNode(*rbtree.Node) bool {
+	panic(ErrReadOnlyView)
+}
+
+// UpdateKey panics with ErrReadOnlyView because view is read only
+func (r *readView) UpdateKey(*rbtree.Node, rbtree.Comparable) bool {
+	panic(ErrReadOnlyView)
+}
+
+// Subscribe does nothing because view content never changes
+func (r *readView) Subscribe(rbtree.EventHandler, rbtree.EventKind) func() {
+	return func() {}
+}
+
+// Begin does nothing because view is read only
+func (r *readView) Begin() {}
+
+// Commit always returns ErrReadOnlyView
+func (r *readView) Commit() error {
+	return ErrReadOnlyView
+}
+
+// Rollback always returns ErrReadOnlyView
+func (r *readView) Rollback() error {
+	return ErrReadOnlyView
+}
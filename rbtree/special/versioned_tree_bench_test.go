@@ -0,0 +1,51 @@
+package special
+
+import (
+	"github.com/aegoroff/godatastruct/rbtree"
+	"math/rand"
+	"testing"
+)
+
+const treeSizeWrite = 50000
+const batchSize = 16
+
+func Benchmark_VersionedTree_Write(b *testing.B) {
+	// Arrange
+	tree := newBenchVersionedTree()
+
+	// Act
+	for i := 0; i < b.N; i++ {
+		_, _ = tree.Write(insertBatch(rand.Perm(batchSize)...))
+	}
+	b.ReportAllocs()
+}
+
+func Benchmark_VersionedTree_WriteViewHeld(b *testing.B) {
+	// Arrange
+	tree := newBenchVersionedTree()
+
+	// Act
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		view := tree.View()
+		b.StartTimer()
+
+		_, _ = tree.Write(insertBatch(rand.Perm(batchSize)...))
+
+		b.StopTimer()
+		view.Close()
+		b.StartTimer()
+	}
+	b.ReportAllocs()
+}
+
+func newBenchVersionedTree() *VersionedTree {
+	tree := NewVersionedTree()
+	_, _ = tree.Write(func(t rbtree.RbTree) error {
+		for _, n := range rand.Perm(treeSizeWrite) {
+			t.Insert(rbtree.Int(n))
+		}
+		return nil
+	})
+	return tree
+}
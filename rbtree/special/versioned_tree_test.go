@@ -0,0 +1,171 @@
+package special
+
+import (
+	"errors"
+	"github.com/aegoroff/godatastruct/rbtree"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func insertBatch(keys ...int) func(t rbtree.RbTree) error {
+	return func(t rbtree.RbTree) error {
+		for _, k := range keys {
+			t.Insert(rbtree.Int(k))
+		}
+		return nil
+	}
+}
+
+func viewKeys(t rbtree.RbTree) []int {
+	result := make([]int, 0)
+	rbtree.NewAscend(t).Foreach(func(c rbtree.Comparable) {
+		result = append(result, rbtree.GetInt(c))
+	})
+	return result
+}
+
+func Test_VersionedTree_ViewAtHeldVersion_SeesOldContent(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := NewVersionedTree()
+	v1, _ := tree.Write(insertBatch(1, 2, 3))
+	view, _ := tree.ViewAt(v1)
+
+	// Act
+	v2, err := tree.Write(func(t rbtree.RbTree) error {
+		t.Delete(rbtree.Int(2))
+		t.Insert(rbtree.Int(4))
+		return nil
+	})
+
+	// Assert
+	ass.NoError(err)
+	ass.Equal(uint64(1), v1)
+	ass.Equal(uint64(2), v2)
+	ass.Equal(v1, view.Version())
+	ass.Equal([]int{1, 2, 3}, viewKeys(view))
+	latest := tree.View()
+	ass.Equal([]int{1, 3, 4}, viewKeys(latest))
+	ass.Equal([]uint64{1, 2}, tree.Versions())
+	view.Close()
+	latest.Close()
+}
+
+func Test_VersionedTree_UnheldVersions_GarbageCollected(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := NewVersionedTree()
+	v1, _ := tree.Write(insertBatch(1))
+	view, _ := tree.ViewAt(v1)
+	_, _ = tree.Write(insertBatch(2))
+	_, _ = tree.Write(insertBatch(3))
+
+	// Act
+	view.Close()
+	view.Close()
+
+	// Assert
+	ass.Equal([]uint64{3}, tree.Versions())
+	_, err := tree.ViewAt(v1)
+	ass.ErrorIs(err, ErrVersionNotRetained)
+	_, err = tree.ViewAt(100)
+	ass.ErrorIs(err, ErrVersionNotRetained)
+}
+
+func Test_VersionedTree_FailedBatch_Discarded(t *testing.T) {
+	var tests = []struct {
+		name string
+		held bool
+	}{
+		{"in place", false},
+		{"copy", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			tree := NewVersionedTree()
+			_, _ = tree.Write(insertBatch(1, 2))
+			if test.held {
+				defer tree.View().Close()
+			}
+			failure := errors.New("failure")
+
+			// Act
+			n, err := tree.Write(func(t rbtree.RbTree) error {
+				t.Insert(rbtree.Int(3))
+				t.Delete(rbtree.Int(1))
+				return failure
+			})
+
+			// Assert
+			ass.ErrorIs(err, failure)
+			ass.Equal(uint64(1), n)
+			ass.Equal(uint64(1), tree.Version())
+			view := tree.View()
+			ass.Equal([]int{1, 2}, viewKeys(view))
+			view.Close()
+		})
+	}
+}
+
+func Test_VersionedTree_ViewIsReadOnly(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := NewVersionedTree()
+	_, _ = tree.Write(insertBatch(1, 2))
+	view := tree.View()
+	defer view.Close()
+	n, _ := view.SearchNode(rbtree.Int(1))
+
+	// Act
+	view.Begin()
+
+	// Assert
+	ass.PanicsWithValue(ErrReadOnlyView, func() { view.Insert(rbtree.Int(3)) })
+	ass.PanicsWithValue(ErrReadOnlyView, func() { view.ReplaceOrInsert(rbtree.Int(4)) })
+	ass.PanicsWithValue(ErrReadOnlyView, func() { view.Delete(rbtree.Int(1)) })
+	ass.PanicsWithValue(ErrReadOnlyView, func() { view.DeleteAll(rbtree.Int(1)) })
+	ass.PanicsWithValue(ErrReadOnlyView, func() { view.DeleteNode(n) })
+	ass.PanicsWithValue(ErrReadOnlyView, func() { view.UpdateKey(n, rbtree.Int(0)) })
+	ass.ErrorIs(view.Commit(), ErrReadOnlyView)
+	ass.ErrorIs(view.Rollback(), ErrReadOnlyView)
+	view.Subscribe(func(e rbtree.Event) {}, rbtree.EventAll)()
+	ass.Equal([]int{1, 2}, viewKeys(view))
+}
+
+func Test_VersionedTree_ConcurrentReadersAndWriter(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := NewVersionedTree()
+	var wg sync.WaitGroup
+	results := make(chan bool, 100)
+
+	// Act
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 100; i++ {
+			_, _ = tree.Write(insertBatch(i))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			view := tree.View()
+			defer view.Close()
+			keys := viewKeys(view)
+			results <- int64(len(keys)) == int64(view.Version()) && view.Len() == int64(view.Version())
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	// Assert
+	for r := range results {
+		ass.True(r)
+	}
+	ass.Equal([]uint64{100}, tree.Versions())
+}
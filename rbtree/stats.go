@@ -0,0 +1,99 @@
+package rbtree
+
+import "unsafe"
+
+// This file contains RB tree statistics implementation
+
+// TreeStats contains tree shape and memory statistics
+type TreeStats struct {
+	// Count is the number of nodes in the tree
+	Count int64
+
+	// Height is the number of nodes on the longest path from the root to a leaf
+	Height int
+
+	// BlackHeight is the number of black nodes on a path from the root to a leaf
+	BlackHeight int
+
+	// MinLeafDepth is the depth of the closest to the root leaf. The root depth is zero
+	MinLeafDepth int
+
+	// MaxLeafDepth is the depth of the farthest from the root leaf
+	MaxLeafDepth int
+
+	// AvgLeafDepth is the average depth of all leaves
+	AvgLeafDepth float64
+
+	// DepthHistogram contains the number of nodes at each depth (index is depth)
+	DepthHistogram []int64
+
+	// MemoryBytes is approximate memory footprint of the tree structure.
+	// Memory referenced by keys (like strings content) isn't included
+	MemoryBytes int64
+}
+
+type statsFrame struct {
+	n      *Node
+	depth  int
+	blacks int
+}
+
+// Stats calculates tree statistics in a single pass
+func (tree *rbTree) Stats() TreeStats {
+	nodeSize := int64(unsafe.Sizeof(Node{}))
+	stats := TreeStats{
+		MemoryBytes:    int64(unsafe.Sizeof(rbTree{})) + nodeSize,
+		DepthHistogram: make([]int64, 0),
+	}
+	if tree.root.isNil() {
+		return stats
+	}
+
+	var leaves int64
+	var leafDepths int64
+	stats.MinLeafDepth = -1
+
+	stack := []statsFrame{{n: tree.root}}
+	for len(stack) > 0 {
+		top := len(stack) - 1
+		f := stack[top]
+		stack = stack[:top]
+
+		if f.n.color == black {
+			f.blacks++
+		}
+
+		stats.Count++
+		if f.depth == len(stats.DepthHistogram) {
+			stats.DepthHistogram = append(stats.DepthHistogram, 0)
+		}
+		stats.DepthHistogram[f.depth]++
+
+		if f.n.left.isNil() || f.n.right.isNil() {
+			// path to the nil child ends here so black height can be taken
+			stats.BlackHeight = f.blacks
+		}
+
+		if f.n.left.isNil() && f.n.right.isNil() {
+			leaves++
+			leafDepths += int64(f.depth)
+			stats.MaxLeafDepth = max(stats.MaxLeafDepth, f.depth)
+			if stats.MinLeafDepth < 0 || f.depth < stats.MinLeafDepth {
+				stats.MinLeafDepth = f.depth
+			}
+			continue
+		}
+
+		if f.n.right.isNotNil() {
+			stack = append(stack, statsFrame{n: f.n.right, depth: f.depth + 1, blacks: f.blacks})
+		}
+		if f.n.left.isNotNil() {
+			stack = append(stack, statsFrame{n: f.n.left, depth: f.depth + 1, blacks: f.blacks})
+		}
+	}
+
+	stats.Height = len(stats.DepthHistogram)
+	stats.AvgLeafDepth = float64(leafDepths) / float64(leaves)
+	stats.MemoryBytes += stats.Count * nodeSize
+	return stats
+}
@@ -0,0 +1,97 @@
+package rbtree
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func Test_Stats_ValuesAsExpected(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTestTree()
+
+	// Act
+	stats := tree.Stats()
+
+	// Assert
+	ass.Equal(int64(11), stats.Count)
+	ass.Equal(4, stats.Height)
+	ass.Equal([]int64{1, 2, 4, 4}, stats.DepthHistogram)
+	ass.Equal(2, stats.MinLeafDepth)
+	ass.Equal(3, stats.MaxLeafDepth)
+	ass.InDelta(16.0/6.0, stats.AvgLeafDepth, 1e-9)
+	ass.Equal(leftmostBlackHeight(tree.Root()), stats.BlackHeight)
+	ass.Greater(stats.MemoryBytes, int64(11*48))
+}
+
+func Test_StatsEmptyTree_ZeroValues(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+
+	// Act
+	stats := tree.Stats()
+
+	// Assert
+	ass.Equal(int64(0), stats.Count)
+	ass.Equal(0, stats.Height)
+	ass.Equal(0, stats.BlackHeight)
+	ass.Empty(stats.DepthHistogram)
+	ass.Greater(stats.MemoryBytes, int64(0))
+}
+
+func Test_StatsOneNode_ValuesAsExpected(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTree([]int{1})
+
+	// Act
+	stats := tree.Stats()
+
+	// Assert
+	ass.Equal(int64(1), stats.Count)
+	ass.Equal(1, stats.Height)
+	ass.Equal(1, stats.BlackHeight)
+	ass.Equal(0, stats.MinLeafDepth)
+	ass.Equal(0, stats.MaxLeafDepth)
+	ass.Equal([]int64{1}, stats.DepthHistogram)
+}
+
+func Test_StatsLargeRandomTree_Balanced(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	r := rand.New(rand.NewSource(1000))
+	tree := New()
+	for i := 0; i < 10000; i++ {
+		tree.Insert(Int(r.Int()))
+	}
+	for i := 0; i < 3000; i++ {
+		tree.DeleteNode(tree.Root())
+	}
+
+	// Act
+	stats := tree.Stats()
+
+	// Assert
+	var total int64
+	for _, c := range stats.DepthHistogram {
+		total += c
+	}
+	ass.Equal(tree.Len(), stats.Count)
+	ass.Equal(stats.Count, total)
+	ass.LessOrEqual(float64(stats.Height), 2*math.Log2(float64(stats.Count+1)))
+	ass.LessOrEqual(stats.MaxLeafDepth, 2*stats.MinLeafDepth+1)
+	ass.Equal(leftmostBlackHeight(tree.Root()), stats.BlackHeight)
+}
+
+func leftmostBlackHeight(n *Node) int {
+	var h int
+	for ; n.isNotNil(); n = n.left {
+		if n.Color() == Black {
+			h++
+		}
+	}
+	return h
+}
@@ -1,5 +1,10 @@
 package rbtree
 
+import (
+	"reflect"
+	"strings"
+)
+
 const (
 	// black RB tree node
 	black = iota
@@ -8,14 +13,66 @@ const (
 	red
 )
 
+// Color represents red-black tree node color
+type Color int
+
+const (
+	// Black node color
+	Black Color = black
+
+	// Red node color
+	Red Color = red
+)
+
 // New creates new empty Red-Black tree
 func New() RbTree {
 	return newRbTree()
 }
 
+// NewWithCodec creates new empty Red-Black tree that uses KeyCodec specified
+// for binary serialization of its keys
+func NewWithCodec(codec KeyCodec) RbTree {
+	tree := newRbTree()
+	tree.codec = codec
+	return tree
+}
+
+// Clone creates a copy of the tree specified that contains the same keys.
+// The copy is perfectly balanced and built in linear time if tree was created by this package.
+// Keys are shared between trees while observers and transaction journal aren't copied
+func Clone(t RbTree) RbTree {
+	src, ok := t.(*rbTree)
+	if !ok {
+		c := New()
+		for n := t.Minimum(); n != nil; n = n.Successor() {
+			c.Insert(n.key)
+		}
+		return c
+	}
+
+	c := newRbTree()
+	c.codec = src.codec
+	c.keyType = src.keyType
+	c.hasher = src.hasher
+	keys := make([]Comparable, 0, src.Len())
+	for n := src.Minimum(); n != nil; n = n.Successor() {
+		keys = append(keys, n.key)
+	}
+	c.build(keys)
+	return c
+}
+
 type rbTree struct {
-	root *Node
-	tnil *Node
+	root    *Node
+	tnil    *Node
+	codec   KeyCodec
+	keyType reflect.Type
+	hasher  KeyHasher
+
+	observers []*subscription
+
+	journal    []journalEntry
+	savepoints []int
 }
 
 // Node represent red-black tree node
@@ -25,6 +82,9 @@ type Node struct {
 	// Subtree size including node itself
 	size int64
 
+	// Subtree hash including node itself. Always zero if tree has no KeyHasher
+	hash uint64
+
 	color  int
 	parent *Node
 	left   *Node
@@ -45,6 +105,11 @@ func (n *Node) Key() Comparable {
 	return n.key
 }
 
+// Color gets Node's color
+func (n *Node) Color() Color {
+	return Color(n.color)
+}
+
 // Size gets subtree size including node itself
 func (n *Node) Size() int64 {
 	return n.size
@@ -88,10 +153,22 @@ func (x *String) Equal(y Comparable) bool {
 	return *x == *(y.(*String))
 }
 
+// HasPrefix gets whether the string starts with the prefix specified
+func (x *String) HasPrefix(prefix Comparable) bool {
+	return strings.HasPrefix(string(*x), string(*(prefix.(*String))))
+}
+
 func (x *String) String() string {
 	return string(*x)
 }
 
+func (c Color) String() string {
+	if c == Red {
+		return "red"
+	}
+	return "black"
+}
+
 // GetInt gets int value from Comparable
 func GetInt(c Comparable) int {
 	return int(c.(Int))
@@ -96,6 +96,80 @@ func Benchmark_RbTree_Search(b *testing.B) {
 	b.ReportAllocs()
 }
 
+func Benchmark_RbTree_SearchSequential(b *testing.B) {
+	// Arrange
+	tree := New()
+	for i := range treeSizeSearchOrIterate {
+		tree.Insert(Int(i))
+	}
+
+	// Act
+	for i := 0; i < b.N; i++ {
+		for j := range treeSizeSearchOrIterate {
+			tree.SearchNode(Int(j))
+		}
+	}
+	b.ReportAllocs()
+}
+
+func Benchmark_RbTree_SearchFromSequential(b *testing.B) {
+	// Arrange
+	tree := New()
+	for i := range treeSizeSearchOrIterate {
+		tree.Insert(Int(i))
+	}
+
+	// Act
+	for i := 0; i < b.N; i++ {
+		finger := tree.Minimum()
+		for j := range treeSizeSearchOrIterate {
+			finger, _ = tree.SearchFrom(finger, Int(j))
+		}
+	}
+	b.ReportAllocs()
+}
+
+func Benchmark_FrozenTree_Search(b *testing.B) {
+	// Arrange
+	tree := New()
+	nodes := generateRandomStrings(treeSizeSearchOrIterate, maxStringLength)
+
+	for i := range treeSizeSearchOrIterate {
+		tree.Insert(nodes[i])
+	}
+	frozen := Freeze(tree)
+
+	unexist := generateRandomStrings(searches, maxStringLength)
+
+	off := rand.Intn(treeSizeSearchOrIterate / 2)
+
+	// Act
+	for i := 0; i < b.N; i++ {
+		for j := range searches {
+			frozen.Search(nodes[j+off])
+			frozen.Search(unexist[j])
+		}
+	}
+	b.ReportAllocs()
+}
+
+func Benchmark_FrozenTree_SearchSequential(b *testing.B) {
+	// Arrange
+	tree := New()
+	for i := range treeSizeSearchOrIterate {
+		tree.Insert(Int(i))
+	}
+	frozen := Freeze(tree)
+
+	// Act
+	for i := 0; i < b.N; i++ {
+		for j := range treeSizeSearchOrIterate {
+			frozen.Search(Int(j))
+		}
+	}
+	b.ReportAllocs()
+}
+
 func Benchmark_BTree_Search(b *testing.B) {
 	// Arrange
 	tree := btree.New(bTreeDegree)
@@ -182,6 +256,23 @@ func Benchmark_RbTree_Ascend(b *testing.B) {
 	_, _ = fmt.Fprintf(bytes.NewBuffer(nil), "%v", x)
 }
 
+func Benchmark_FrozenTree_Ascend(b *testing.B) {
+	ints := perm(treeSizeSearchOrIterate)
+	tree := New()
+	for _, n := range ints {
+		tree.Insert(Int(n))
+	}
+	frozen := Freeze(tree)
+	x := 0
+	for i := 0; i < b.N; i++ {
+		frozen.NewAscend().Foreach(func(c Comparable) {
+			x = int(c.(Int))
+		})
+	}
+	b.ReportAllocs()
+	_, _ = fmt.Fprintf(bytes.NewBuffer(nil), "%v", x)
+}
+
 func Benchmark_BTree_Ascend(b *testing.B) {
 	ints := perm(treeSizeSearchOrIterate)
 	tree := btree.New(bTreeDegree)
@@ -234,6 +325,18 @@ func Benchmark_BTree_Descend(b *testing.B) {
 	_, _ = fmt.Fprintf(bytes.NewBuffer(nil), "%v", x)
 }
 
+func Benchmark_Freeze(b *testing.B) {
+	ints := perm(treeSizeSearchOrIterate)
+	tree := New()
+	for _, n := range ints {
+		tree.Insert(Int(n))
+	}
+	for i := 0; i < b.N; i++ {
+		Freeze(tree)
+	}
+	b.ReportAllocs()
+}
+
 // perm returns a random permutation of n Int items in the range [0, n).
 func perm(n int) (out []int) {
 	out = append(out, rand.Perm(n)...)
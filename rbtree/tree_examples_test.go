@@ -1,6 +1,8 @@
 package rbtree
 
 import (
+	"encoding"
+	"encoding/json"
 	"fmt"
 )
 
@@ -86,3 +88,210 @@ func ExampleNode_Predecessor() {
 	// Output:
 	// 3
 }
+
+func ExampleRbTree_EqualRange() {
+	tree := New()
+
+	tree.Insert(Int(6))
+	tree.Insert(Int(18))
+	tree.Insert(Int(6))
+	tree.Insert(Int(3))
+
+	first, last, count := tree.EqualRange(Int(6))
+	fmt.Println(first.Rank())
+	fmt.Println(last.Rank())
+	fmt.Println(count)
+	// Output:
+	// 2
+	// 3
+	// 2
+}
+
+func ExampleRbTree_UpdateKey() {
+	tree := New()
+
+	tree.Insert(Int(6))
+	tree.Insert(Int(18))
+	tree.Insert(Int(3))
+
+	n, _ := tree.SearchNode(Int(3))
+	tree.UpdateKey(n, Int(20))
+
+	NewAscend(tree).Foreach(func(c Comparable) {
+		fmt.Println(c)
+	})
+	// Output:
+	// 6
+	// 18
+	// 20
+}
+
+func ExampleRbTree_SearchFrom() {
+	tree := New()
+
+	for i := 1; i <= 10; i++ {
+		tree.Insert(Int(i * 10))
+	}
+
+	finger := tree.Minimum()
+	for _, v := range []int{20, 30, 35, 50} {
+		n, ok := tree.CeilingFrom(finger, Int(v))
+		if ok {
+			finger = n
+			fmt.Println(n.Key())
+		}
+	}
+	// Output:
+	// 20
+	// 30
+	// 40
+	// 50
+}
+
+func ExampleRbTree_FindFirst() {
+	tree := New()
+
+	tree.Insert(Int(6))
+	tree.Insert(Int(18))
+	tree.Insert(Int(3))
+	tree.Insert(Int(12))
+
+	deadline := func(c Comparable) bool { return GetInt(c) > 10 }
+
+	n, ok := tree.FindFirst(deadline)
+	fmt.Println(n.Key())
+	fmt.Println(ok)
+	fmt.Println(tree.PartitionPoint(deadline))
+	// Output:
+	// 12
+	// true
+	// 2
+}
+
+func ExampleRbTree_Quantile() {
+	tree := New()
+
+	for i := 1; i <= 10; i++ {
+		tree.Insert(Int(i))
+	}
+
+	q, _ := tree.Quantile(0.9)
+	fmt.Println(q)
+	m, _ := tree.Median()
+	fmt.Println(m)
+	f, _ := tree.InterpolatedQuantile(0.5)
+	fmt.Println(f)
+	// Output:
+	// 9
+	// 5
+	// 5.5
+}
+
+func ExampleRbTree_Stats() {
+	tree := New()
+
+	for i := 1; i <= 10; i++ {
+		tree.Insert(Int(i))
+	}
+
+	stats := tree.Stats()
+	fmt.Println(stats.Count)
+	fmt.Println(stats.Height)
+	fmt.Println(stats.DepthHistogram)
+	// Output:
+	// 10
+	// 5
+	// [1 2 4 2 1]
+}
+
+func ExampleNewWithCodec() {
+	tree := NewWithCodec(Int64Codec)
+
+	tree.Insert(Int64(6))
+	tree.Insert(Int64(18))
+	tree.Insert(Int64(3))
+
+	data, _ := tree.(encoding.BinaryMarshaler).MarshalBinary()
+
+	restored := NewWithCodec(Int64Codec)
+	_ = restored.(encoding.BinaryUnmarshaler).UnmarshalBinary(data)
+
+	NewAscend(restored).Foreach(func(c Comparable) {
+		fmt.Println(c)
+	})
+	// Output:
+	// 3
+	// 6
+	// 18
+}
+
+func ExampleNewWithKeyType() {
+	tree, _ := NewWithKeyType("int64")
+
+	_ = json.Unmarshal([]byte("[18, 3, 6]"), tree)
+
+	data, _ := json.Marshal(tree)
+	fmt.Println(string(data))
+	// Output:
+	// [3,6,18]
+}
+
+func ExampleNewHashed() {
+	hasher := func(c Comparable) uint64 { return uint64(c.(Int)) }
+	a := NewHashed(hasher)
+	b := NewHashed(hasher)
+
+	a.Insert(Int(6))
+	a.Insert(Int(18))
+	a.Insert(Int(3))
+
+	b.Insert(Int(3))
+	b.Insert(Int(6))
+	b.Insert(Int(20))
+
+	fmt.Println(a.RootHash() == b.RootHash())
+	fmt.Println(a.RangeHash(Int(0), Int(10)) == b.RangeHash(Int(0), Int(10)))
+	// Output:
+	// false
+	// true
+}
+
+func ExampleTuple2() {
+	tree := New()
+
+	// order by score descending then by name ascending
+	tree.Insert(Tuple2(Reverse(Int(10)), NewString("bob")))
+	tree.Insert(Tuple2(Reverse(Int(20)), NewString("eve")))
+	tree.Insert(Tuple2(Reverse(Int(10)), NewString("alice")))
+
+	NewAscend(tree).Foreach(func(c Comparable) {
+		t := c.(Tuple)
+		fmt.Println(t[0].(Reversed).Key, t[1])
+	})
+	// Output:
+	// 20 eve
+	// 10 alice
+	// 10 bob
+}
+
+func ExampleFreeze() {
+	tree := New()
+	for _, n := range []int{6, 18, 3, 15, 7, 2, 4, 13, 9, 17, 20} {
+		tree.Insert(Int(n))
+	}
+
+	frozen := Freeze(tree)
+
+	floor, _ := frozen.Floor(Int(12))
+	rank, _ := frozen.Rank(Int(15))
+	fmt.Println(floor, rank)
+
+	frozen.NewAscendRange(Int(5), Int(10)).Foreach(func(c Comparable) {
+		fmt.Println(c)
+	})
+	// Output:
+	// 9 8
+	// 6
+	// 7
+	// 9
+}
@@ -134,6 +134,26 @@ func Test_DeleteFromLargeTree_SpecifiedNodeColorBlack(t *testing.T) {
 	ass.Equal(black, foundAfterDelete.color)
 }
 
+func Test_DeleteRandomNodesWithDuplicates_SubtreeSizesValid(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	r := rand.New(rand.NewSource(1000))
+	tree := newRbTree()
+	nodes := make([]int, 2000)
+	for i := range nodes {
+		nodes[i] = r.Intn(300)
+		tree.Insert(Int(nodes[i]))
+	}
+
+	for i := 0; i < 1500; i++ {
+		// Act
+		tree.Delete(Int(nodes[i]))
+
+		// Assert
+		ass.Equal(tree.Len(), subtreeSize(tree.root))
+	}
+}
+
 func Test_DeleteAll_EmptyTree(t *testing.T) {
 	// Arrange
 	ass := assert.New(t)
@@ -367,6 +387,133 @@ func Test_DeleteSeveralNodesWithTheSameKey_ResultAsExpected(t *testing.T) {
 	ass.False(ok3)
 }
 
+func Test_DeleteNodeWithDuplicates_ExactNodeDeleted(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	k1 := NewString("tst")
+	k2 := NewString("tst")
+	k3 := NewString("tst")
+	tree.Insert(k1)
+	tree.Insert(k2)
+	tree.Insert(k3)
+	tree.Insert(NewString("abc"))
+	_, last, _ := tree.EqualRange(k1)
+	deleted := last.Key()
+
+	// Act
+	ok := tree.DeleteNode(last)
+
+	// Assert
+	ass.True(ok)
+	ass.Equal(int64(3), tree.Len())
+	NewEqualRange(tree, k1).Foreach(func(c Comparable) {
+		ass.NotSame(deleted, c)
+	})
+}
+
+func Test_DeleteNode_ResultAsExpected(t *testing.T) {
+	deleted := newIntTestTree()
+	n, _ := deleted.SearchNode(Int(9))
+	deleted.DeleteNode(n)
+	foreign, _ := newIntTestTree().SearchNode(Int(13))
+
+	var tests = []struct {
+		name   string
+		tree   RbTree
+		node   *Node
+		result bool
+	}{
+		{"nil", newIntTestTree(), nil, false},
+		{"already deleted", deleted, n, false},
+		{"other tree node", deleted, foreign, false},
+		{"manually created", newIntTestTree(), newNode(Int(13)), false},
+		{"empty tree", New(), foreign, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			l := test.tree.Len()
+
+			// Act
+			ok := test.tree.DeleteNode(test.node)
+
+			// Assert
+			ass.Equal(test.result, ok)
+			ass.Equal(l, test.tree.Len())
+		})
+	}
+}
+
+func Test_DeleteNodeAll_EmptyTree(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTestTree()
+
+	// Act
+	for tree.Len() > 0 {
+		ass.True(tree.DeleteNode(tree.Root()))
+	}
+
+	// Assert
+	ass.Nil(tree.Minimum())
+}
+
+func Test_UpdateKey_OrderAndSizesValid(t *testing.T) {
+	var tests = []struct {
+		name     string
+		from     int
+		to       int
+		expected []int
+	}{
+		{"in place", 9, 10, []int{2, 3, 4, 6, 7, 10, 13, 15, 17, 18, 20}},
+		{"in place equal to neighbour", 9, 7, []int{2, 3, 4, 6, 7, 7, 13, 15, 17, 18, 20}},
+		{"move to the beginning", 13, 1, []int{1, 2, 3, 4, 6, 7, 9, 15, 17, 18, 20}},
+		{"move to the end", 6, 21, []int{2, 3, 4, 7, 9, 13, 15, 17, 18, 20, 21}},
+		{"move inside", 2, 14, []int{3, 4, 6, 7, 9, 13, 14, 15, 17, 18, 20}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			tree := newIntTestTree()
+			n, _ := tree.SearchNode(Int(test.from))
+			result := make([]int, 0)
+
+			// Act
+			ok := tree.UpdateKey(n, Int(test.to))
+
+			// Assert
+			ass.True(ok)
+			ass.Equal(test.to, GetInt(n.Key()))
+			NewAscend(tree).Foreach(func(c Comparable) {
+				result = append(result, GetInt(c))
+			})
+			ass.Equal(test.expected, result)
+			ass.Equal(int64(len(test.expected)), subtreeSize(tree.Root()))
+		})
+	}
+}
+
+func Test_UpdateKeyInvalidArguments_NotUpdated(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTestTree()
+	n, _ := tree.SearchNode(Int(9))
+
+	// Act
+	ok1 := tree.UpdateKey(n, nil)
+	ok2 := tree.UpdateKey(nil, Int(1))
+	ok3 := tree.UpdateKey(newNode(Int(2)), Int(1))
+
+	// Assert
+	ass.False(ok1)
+	ass.False(ok2)
+	ass.False(ok3)
+	ass.Equal(9, GetInt(n.Key()))
+}
+
 func Test_InsertAndCheckLen(t *testing.T) {
 	// Arrange
 	ass := assert.New(t)
@@ -540,3 +687,46 @@ func newStringTree(nodes []string) *rbTree {
 	}
 	return tree
 }
+
+// subtreeSize calculates subtree size and validates that all nodes' sizes are consistent
+func subtreeSize(n *Node) int64 {
+	if n.isNil() {
+		return 0
+	}
+	s := subtreeSize(n.left) + subtreeSize(n.right) + 1
+	if s != n.size {
+		return -1
+	}
+	return s
+}
+
+func Test_Clone_SameKeysIndependentTree(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newIntTestTree()
+
+	// Act
+	c := Clone(tree)
+	c.Insert(Int(100))
+	tree.Delete(Int(6))
+
+	// Assert
+	ass.True(isValidRbTree(c))
+	ass.Equal([]int{2, 3, 4, 6, 7, 9, 13, 15, 17, 18, 20, 100}, ascendInts(c))
+	ass.Equal([]int{2, 3, 4, 7, 9, 13, 15, 17, 18, 20}, ascendInts(tree))
+}
+
+func Test_Clone_HashedTree_HashesKept(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := NewHashed(intHasher)
+	for i := 0; i < 50; i++ {
+		tree.Insert(Int(i))
+	}
+
+	// Act
+	c := Clone(tree).(HashedTree)
+
+	// Assert
+	ass.Equal(tree.RootHash(), c.RootHash())
+}
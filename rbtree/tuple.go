@@ -0,0 +1,118 @@
+package rbtree
+
+// This file contains composite keys and ordering combinators
+
+// Tuple is the composite key that compares its elements lexicographically.
+// If one tuple is a prefix of another the shorter tuple is less so a tuple with
+// only leading fields can be used with Ceiling or range iteration to find all keys starting with them.
+// Elements at the same position must have the same type. Use Reverse for descending fields
+type Tuple []Comparable
+
+// Reversed is the key that reverses its underlying key order
+type Reversed struct {
+	// Key is the underlying key
+	Key Comparable
+}
+
+// Nullable is the key that may have no value (null). Nulls are equal to each other and
+// ordered before or after all other values depending on how the key was created
+type Nullable struct {
+	// Key is the underlying key or nil for null
+	Key Comparable
+
+	nullsLast bool
+}
+
+// Tuple2 creates two elements Tuple
+func Tuple2(a Comparable, b Comparable) Tuple {
+	return Tuple{a, b}
+}
+
+// Tuple3 creates three elements Tuple
+func Tuple3(a Comparable, b Comparable, c Comparable) Tuple {
+	return Tuple{a, b, c}
+}
+
+// Reverse creates key that orders in the reverse order of the key specified.
+// It can be used to make descending tree or descending Tuple field
+func Reverse(c Comparable) Reversed {
+	return Reversed{Key: c}
+}
+
+// NullsFirst creates Nullable key that is less than any non null value if c is nil
+func NullsFirst(c Comparable) Nullable {
+	return Nullable{Key: c}
+}
+
+// NullsLast creates Nullable key that is greater than any non null value if c is nil
+func NullsLast(c Comparable) Nullable {
+	return Nullable{Key: c, nullsLast: true}
+}
+
+// Less define Comparable interface member for Tuple
+func (x Tuple) Less(y Comparable) bool {
+	other := y.(Tuple)
+	for i := 0; i < len(x) && i < len(other); i++ {
+		if x[i].Less(other[i]) {
+			return true
+		}
+		if other[i].Less(x[i]) {
+			return false
+		}
+	}
+	return len(x) < len(other)
+}
+
+// Equal define Comparable interface member for Tuple
+func (x Tuple) Equal(y Comparable) bool {
+	other, ok := y.(Tuple)
+	if !ok || len(x) != len(other) {
+		return false
+	}
+	for i := range x {
+		if !x[i].Equal(other[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Less define Comparable interface member for Reversed
+func (x Reversed) Less(y Comparable) bool {
+	return y.(Reversed).Key.Less(x.Key)
+}
+
+// Equal define Comparable interface member for Reversed
+func (x Reversed) Equal(y Comparable) bool {
+	other, ok := y.(Reversed)
+	return ok && x.Key.Equal(other.Key)
+}
+
+// IsNull gets whether the key has no value
+func (x Nullable) IsNull() bool {
+	return x.Key == nil
+}
+
+// Less define Comparable interface member for Nullable
+func (x Nullable) Less(y Comparable) bool {
+	other := y.(Nullable)
+	switch {
+	case x.IsNull() && other.IsNull():
+		return false
+	case x.IsNull():
+		return !x.nullsLast
+	case other.IsNull():
+		return other.nullsLast
+	default:
+		return x.Key.Less(other.Key)
+	}
+}
+
+// Equal define Comparable interface member for Nullable
+func (x Nullable) Equal(y Comparable) bool {
+	other, ok := y.(Nullable)
+	if !ok || x.IsNull() != other.IsNull() {
+		return false
+	}
+	return x.IsNull() || x.Key.Equal(other.Key)
+}
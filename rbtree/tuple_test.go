@@ -0,0 +1,175 @@
+package rbtree
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newScoreTree() RbTree {
+	tree := New()
+	tree.Insert(Tuple2(Reverse(Int(10)), NewString("bob")))
+	tree.Insert(Tuple2(Reverse(Int(20)), NewString("eve")))
+	tree.Insert(Tuple2(Reverse(Int(10)), NewString("alice")))
+	tree.Insert(Tuple2(Reverse(Int(5)), NewString("carl")))
+	tree.Insert(Tuple2(Reverse(Int(20)), NewString("dave")))
+	return tree
+}
+
+func tupleString(c Comparable) string {
+	t := c.(Tuple)
+	return fmt.Sprintf("%d:%s", GetInt(t[0].(Reversed).Key), t[1])
+}
+
+func Test_Tuple_ScoreDescNameAsc_Ordered(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newScoreTree()
+
+	// Act
+	var result []string
+	NewAscend(tree).Foreach(func(c Comparable) {
+		result = append(result, tupleString(c))
+	})
+
+	// Assert
+	ass.Equal([]string{"20:dave", "20:eve", "10:alice", "10:bob", "5:carl"}, result)
+	ass.True(isValidRbTree(tree))
+}
+
+func Test_Tuple_PrefixCeilingAndRange(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newScoreTree()
+
+	// Act
+	first, ok := tree.Ceiling(Tuple{Reverse(Int(10))})
+	var inRange []string
+	NewOpenAscendRange(tree, Tuple{Reverse(Int(10))}, Tuple{Reverse(Int(9))}).Foreach(func(c Comparable) {
+		inRange = append(inRange, tupleString(c))
+	})
+
+	// Assert
+	ass.True(ok)
+	ass.Equal("10:alice", tupleString(first))
+	ass.Equal([]string{"10:alice", "10:bob"}, inRange)
+}
+
+func Test_Tuple_Floor(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := newScoreTree()
+
+	// Act
+	found, ok := tree.Floor(Tuple2(Reverse(Int(10)), NewString("b")))
+
+	// Assert
+	ass.True(ok)
+	ass.Equal("10:alice", tupleString(found))
+}
+
+func Test_Tuple_LessAndEqual(t *testing.T) {
+	var tests = []struct {
+		name  string
+		x     Tuple
+		y     Tuple
+		less  bool
+		equal bool
+	}{
+		{"first field less", Tuple2(Int(1), Int(5)), Tuple2(Int(2), Int(0)), true, false},
+		{"second field less", Tuple2(Int(1), Int(1)), Tuple2(Int(1), Int(2)), true, false},
+		{"equal", Tuple3(Int(1), Int(2), Int(3)), Tuple3(Int(1), Int(2), Int(3)), false, true},
+		{"prefix", Tuple{Int(1)}, Tuple2(Int(1), Int(0)), true, false},
+		{"greater", Tuple2(Int(2), Int(0)), Tuple2(Int(1), Int(9)), false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+
+			// Act
+			less := test.x.Less(test.y)
+			equal := test.x.Equal(test.y)
+
+			// Assert
+			ass.Equal(test.less, less)
+			ass.Equal(test.equal, equal)
+		})
+	}
+}
+
+func Test_Reverse_DescendingTree(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	for _, k := range []int{3, 1, 2} {
+		tree.Insert(Reverse(Int(k)))
+	}
+
+	// Act
+	var result []int
+	NewAscend(tree).Foreach(func(c Comparable) {
+		result = append(result, GetInt(c.(Reversed).Key))
+	})
+
+	// Assert
+	ass.Equal([]int{3, 2, 1}, result)
+	ass.True(Reverse(Int(1)).Equal(Reverse(Int(1))))
+	ass.False(Reverse(Int(1)).Equal(Int(1)))
+}
+
+func Test_Nullable_NullsOrdering(t *testing.T) {
+	var tests = []struct {
+		name     string
+		wrap     func(Comparable) Nullable
+		expected []string
+	}{
+		{"nulls first", NullsFirst, []string{"null", "null", "1", "2"}},
+		{"nulls last", NullsLast, []string{"1", "2", "null", "null"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ass := assert.New(t)
+			tree := New()
+			tree.Insert(test.wrap(Int(2)))
+			tree.Insert(test.wrap(nil))
+			tree.Insert(test.wrap(Int(1)))
+			tree.Insert(test.wrap(nil))
+
+			// Act
+			var result []string
+			NewAscend(tree).Foreach(func(c Comparable) {
+				n := c.(Nullable)
+				if n.IsNull() {
+					result = append(result, "null")
+				} else {
+					result = append(result, fmt.Sprint(n.Key))
+				}
+			})
+			_, _, nulls := tree.EqualRange(test.wrap(nil))
+
+			// Assert
+			ass.Equal(test.expected, result)
+			ass.Equal(int64(2), nulls)
+		})
+	}
+}
+
+func Test_Nullable_InTuple(t *testing.T) {
+	// Arrange
+	ass := assert.New(t)
+	tree := New()
+	tree.Insert(Tuple2(Int(1), NullsLast(nil)))
+	tree.Insert(Tuple2(Int(1), NullsLast(Int(5))))
+	tree.Insert(Tuple2(Int(0), NullsLast(nil)))
+
+	// Act
+	last, ok := tree.Floor(Tuple2(Int(1), NullsLast(nil)))
+	ceiling, _ := tree.Ceiling(Tuple{Int(1)})
+
+	// Assert
+	ass.True(ok)
+	ass.True(last.(Tuple)[1].(Nullable).IsNull())
+	ass.Equal(Int(5), ceiling.(Tuple)[1].(Nullable).Key)
+}